@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"stresstest/internal/models"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	authBearer         string
+	authBasic          string
+	oauth2TokenURL     string
+	oauth2ClientID     string
+	oauth2ClientSecret string
+	oauth2Scopes       []string
+)
+
+// addAuthFlags registra as flags de autenticação das requisições,
+// compartilhadas entre os comandos que executam testes
+func addAuthFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&authBearer, "auth-bearer", "", "Token enviado no cabeçalho Authorization: Bearer")
+	flags.StringVar(&authBasic, "auth-basic", "", "Credenciais da autenticação basic no formato usuário:senha")
+	flags.StringVar(&oauth2TokenURL, "oauth2-token-url", "", "Endpoint de tokens do fluxo OAuth2 client credentials")
+	flags.StringVar(&oauth2ClientID, "oauth2-client-id", "", "Client id do fluxo OAuth2 client credentials")
+	flags.StringVar(&oauth2ClientSecret, "oauth2-client-secret", "", "Client secret do fluxo OAuth2 client credentials")
+	flags.StringArrayVar(&oauth2Scopes, "oauth2-scope", nil, "Escopo solicitado no fluxo OAuth2 (pode ser repetido)")
+}
+
+// applyAuthOptions aplica a autenticação informada nas flags, substituindo a
+// já definida (por exemplo, no cenário)
+func applyAuthOptions(options *models.AuthOptions) error {
+	configured := 0
+	for _, value := range []string{authBearer, authBasic, oauth2TokenURL} {
+		if value != "" {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return fmt.Errorf("use apenas uma forma de autenticação: --auth-bearer, --auth-basic ou --oauth2-token-url")
+	}
+
+	switch {
+	case authBearer != "":
+		*options = models.AuthOptions{Type: models.AuthBearer, Token: authBearer}
+	case authBasic != "":
+		username, password, found := strings.Cut(authBasic, ":")
+		if !found || username == "" {
+			return fmt.Errorf("credenciais basic inválidas: use o formato usuário:senha")
+		}
+		*options = models.AuthOptions{Type: models.AuthBasic, Username: username, Password: password}
+	case oauth2TokenURL != "":
+		if oauth2ClientID == "" {
+			return fmt.Errorf("--oauth2-client-id é obrigatório com --oauth2-token-url")
+		}
+		*options = models.AuthOptions{
+			Type:         models.AuthOAuth2,
+			TokenURL:     oauth2TokenURL,
+			ClientID:     oauth2ClientID,
+			ClientSecret: oauth2ClientSecret,
+			Scopes:       oauth2Scopes,
+		}
+	case oauth2ClientID != "" || oauth2ClientSecret != "" || len(oauth2Scopes) > 0:
+		return fmt.Errorf("--oauth2-token-url é obrigatório com as demais flags OAuth2")
+	}
+
+	return nil
+}
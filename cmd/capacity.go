@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"stresstest/internal/capacity"
+	"stresstest/internal/models"
+	"stresstest/internal/report"
+	"stresstest/internal/stresstest"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	capacityMode         string
+	capacityStart        float64
+	capacityFactor       float64
+	capacityMax          float64
+	capacityPrecision    float64
+	capacityStepDuration time.Duration
+	capacityMaxInFlight  int
+	capacityMaxErrorRate float64
+	capacityMaxP99       time.Duration
+)
+
+// capacityCmd busca automaticamente o ponto de ruptura do serviço
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Busca automaticamente a capacidade máxima sustentável do serviço",
+	Long: `Aumenta a concorrência ou a taxa de requisições em degraus até que o SLO
+(taxa de erros ou latência p99) seja violado e, em seguida, faz uma bisseção
+entre o último nível aprovado e o primeiro reprovado para encontrar a maior
+vazão sustentável. Ao final exibe todos os pontos medidos e o joelho da curva.
+
+Exemplos de uso:
+  stresstest capacity --url=http://localhost:8080 --max-error-rate=1 --max-p99=300ms
+  stresstest capacity --url=http://localhost:8080 --mode=rate --start=50 --step-duration=1m`,
+	RunE: runCapacity,
+}
+
+func init() {
+	capacityCmd.Flags().StringVar(&targetURL, "url", "", "URL do serviço a ser testado (obrigatório sem --requests-file)")
+	addRequestFlags(capacityCmd.Flags())
+	addClientFlags(capacityCmd.Flags())
+	addAuthFlags(capacityCmd.Flags())
+	addCheckFlags(capacityCmd.Flags())
+	capacityCmd.Flags().StringVar(&capacityMode, "mode", capacity.ModeConcurrency, "Grandeza aumentada a cada degrau: concurrency ou rate")
+	capacityCmd.Flags().Float64Var(&capacityStart, "start", 10, "Nível inicial de carga (usuários ou req/s)")
+	capacityCmd.Flags().Float64Var(&capacityFactor, "factor", 2, "Fator de multiplicação do nível a cada degrau")
+	capacityCmd.Flags().Float64Var(&capacityMax, "max", 10000, "Nível máximo de carga avaliado")
+	capacityCmd.Flags().Float64Var(&capacityPrecision, "precision", 5, "Precisão relativa (%) em que a bisseção termina")
+	capacityCmd.Flags().DurationVar(&capacityStepDuration, "step-duration", 30*time.Second, "Duração de cada medição")
+	capacityCmd.Flags().IntVar(&capacityMaxInFlight, "max-inflight", 1000, "Limite de requisições em andamento no modo rate")
+	capacityCmd.Flags().Float64Var(&capacityMaxErrorRate, "max-error-rate", 1, "SLO: percentual máximo de requisições com falha")
+	capacityCmd.Flags().DurationVar(&capacityMaxP99, "max-p99", 0, "SLO: latência p99 máxima (ex: 250ms); 0 desativa")
+
+	rootCmd.AddCommand(capacityCmd)
+}
+
+// runCapacity executa a busca de capacidade
+func runCapacity(cmd *cobra.Command, args []string) error {
+	if err := validateCapacityParameters(); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	base := models.TestConfig{URL: targetURL}
+	if err := applyRequestOptions(&base); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	if err := applyClientOptions(&base.Client); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	if err := applyAuthOptions(&base.Auth); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	applyCheckOptions(&base.Checks)
+
+	// A validação de um teste comum é aplicada à medição de maior carga, para
+	// recusar antes da busca, por exemplo, fontes de dados sem registros
+	// exclusivos suficientes para todos os workers
+	if err := validateLoad(capacityPeak(base)); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	ctx, cancel := interruptibleContext(os.Stdout)
+	defer cancel()
+
+	options := capacity.Options{
+		Mode:         capacityMode,
+		Start:        capacityStart,
+		Factor:       capacityFactor,
+		Max:          capacityMax,
+		Precision:    capacityPrecision,
+		StepDuration: capacityStepDuration,
+		MaxInFlight:  capacityMaxInFlight,
+		SLO: capacity.SLO{
+			MaxErrorRate: capacityMaxErrorRate,
+			MaxP99:       capacityMaxP99,
+		},
+	}
+
+	// As medições individuais não exibem cabeçalho nem progresso
+	executor := stresstest.NewExecutor()
+	executor.SetOutput(io.Discard)
+
+	// Os erros a partir daqui não são de uso: a ajuda do comando não é exibida
+	cmd.SilenceUsage = true
+
+	unit := capacityUnit(capacityMode)
+	formatter := report.NewFormatter()
+	search := capacity.NewSearch(executor, base, options)
+	search.OnPoint(func(point models.CapacityPoint) {
+		formatter.PrintCapacityPoint(unit, point)
+	})
+
+	fmt.Printf("Iniciando busca de capacidade (%s)...\n", capacityMode)
+	result, err := search.Run(ctx)
+	formatter.PrintCapacityReport(result, unit)
+	if ctx.Err() != nil {
+		return interrupted(fmt.Errorf("busca de capacidade interrompida: o resultado é parcial"))
+	}
+	if err != nil {
+		return fmt.Errorf("erro durante a busca de capacidade: %w", err)
+	}
+
+	return nil
+}
+
+// capacityPeak retorna a configuração da medição no nível máximo da busca
+func capacityPeak(base models.TestConfig) models.TestConfig {
+	config := base
+	config.Duration = capacityStepDuration
+	if capacityMode == capacity.ModeRate {
+		config.Rate = capacityMax
+		config.Concurrency = capacityMaxInFlight
+	} else {
+		config.Concurrency = int(capacityMax)
+	}
+	return config
+}
+
+// capacityUnit retorna a unidade do nível de carga exibida no relatório
+func capacityUnit(mode string) string {
+	if mode == capacity.ModeRate {
+		return "req/s"
+	}
+	return "usuários"
+}
+
+// validateCapacityParameters valida os parâmetros da busca de capacidade
+func validateCapacityParameters() error {
+	if err := validateTarget(); err != nil {
+		return err
+	}
+
+	if capacityMode != capacity.ModeConcurrency && capacityMode != capacity.ModeRate {
+		return fmt.Errorf("modo deve ser %q ou %q", capacity.ModeConcurrency, capacity.ModeRate)
+	}
+
+	if capacityStart < 1 {
+		return fmt.Errorf("nível inicial deve ser pelo menos 1")
+	}
+
+	if capacityFactor <= 1 {
+		return fmt.Errorf("fator de multiplicação deve ser maior que 1")
+	}
+
+	if capacityMax < capacityStart {
+		return fmt.Errorf("nível máximo não pode ser menor que o inicial")
+	}
+
+	if capacityMode == capacity.ModeConcurrency && capacityMax > 10000 {
+		return fmt.Errorf("nível de concorrência não pode exceder 10.000")
+	}
+
+	if capacityMode == capacity.ModeRate && capacityMax > 100000 {
+		return fmt.Errorf("taxa não pode exceder 100.000 req/s")
+	}
+
+	if capacityPrecision <= 0 || capacityPrecision >= 100 {
+		return fmt.Errorf("precisão deve estar entre 0 e 100%%")
+	}
+
+	if capacityStepDuration <= 0 {
+		return fmt.Errorf("duração de cada medição deve ser maior que 0")
+	}
+
+	if capacityMaxInFlight <= 0 || capacityMaxInFlight > 10000 {
+		return fmt.Errorf("limite de requisições em andamento deve estar entre 1 e 10.000")
+	}
+
+	if capacityMaxErrorRate < 0 || capacityMaxErrorRate > 100 {
+		return fmt.Errorf("taxa máxima de erros deve estar entre 0 e 100%%")
+	}
+
+	return nil
+}
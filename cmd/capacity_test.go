@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// parseCapacityFlags interpreta os argumentos do comando capacity a partir das
+// flags padrão, que são restauradas ao fim do teste
+func parseCapacityFlags(t *testing.T, args ...string) {
+	t.Helper()
+	reset := func() {
+		capacityCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				slice.Replace(nil)
+			} else {
+				flag.Value.Set(flag.DefValue)
+			}
+			flag.Changed = false
+		})
+	}
+	reset()
+	t.Cleanup(reset)
+	if err := capacityCmd.Flags().Parse(args); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestRunCapacityValidatesFeedersBeforeSearching(t *testing.T) {
+	feeder := filepath.Join(t.TempDir(), "usuarios.csv")
+	if err := os.WriteFile(feeder, []byte("user\nana\nbeto\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "concurrency", args: []string{"--start=1", "--max=5"}},
+		{name: "rate", args: []string{"--mode=rate", "--max=50", "--max-inflight=3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// O endereço não aceita conexões: a busca não pode chegar a começar
+			parseCapacityFlags(t, append([]string{
+				"--url=http://127.0.0.1:1", "--feeder=" + feeder,
+				"--feeder-strategy=unique", "--feeder-exhausted=stop",
+			}, tt.args...)...)
+
+			err := runCapacity(capacityCmd, nil)
+			if exitCode(err) != exitInvalidParameters || !strings.Contains(err.Error(), "usuarios.csv tem 2 registros") {
+				t.Errorf("Expected the feeder to be rejected as an invalid parameter, got %v", err)
+			}
+		})
+	}
+}
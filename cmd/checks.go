@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"stresstest/internal/models"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	expectStatus     []int
+	expectBody       []string
+	expectBodyRegex  []string
+	expectJSON       []string
+	expectHeaders    []string
+	expectMaxLatency time.Duration
+	expectMinSize    int64
+	expectMaxSize    int64
+)
+
+// addCheckFlags registra as flags de verificação das respostas,
+// compartilhadas entre os comandos que executam testes
+func addCheckFlags(flags *pflag.FlagSet) {
+	flags.IntSliceVar(&expectStatus, "expect-status", nil, "Códigos de status aceitos, separados por vírgula (padrão: qualquer 2xx)")
+	flags.StringArrayVar(&expectBody, "expect-body", nil, "Trecho que o corpo da resposta deve conter (pode ser repetida)")
+	flags.StringArrayVar(&expectBodyRegex, "expect-body-regex", nil, "Expressão regular que o corpo da resposta deve satisfazer (pode ser repetida)")
+	flags.StringArrayVar(&expectJSON, "expect-json", nil, "Campo JSON esperado no formato caminho=valor, ou apenas caminho para exigir a presença (pode ser repetida)")
+	flags.StringArrayVar(&expectHeaders, "expect-header", nil, "Cabeçalho que deve estar presente na resposta (pode ser repetida)")
+	flags.DurationVar(&expectMaxLatency, "expect-max-latency", 0, "Duração máxima de cada requisição (ex: 500ms)")
+	flags.Int64Var(&expectMinSize, "expect-min-size", 0, "Tamanho mínimo do corpo da resposta, em bytes")
+	flags.Int64Var(&expectMaxSize, "expect-max-size", 0, "Tamanho máximo do corpo da resposta, em bytes")
+}
+
+// applyCheckOptions aplica as verificações informadas nas flags a todas as
+// requisições sem verificações próprias, substituindo as já definidas (por
+// exemplo, no cenário). A validação acontece na compilação das requisições
+func applyCheckOptions(checks **models.Checks) {
+	configured := models.Checks{
+		Status:       expectStatus,
+		BodyContains: expectBody,
+		BodyRegex:    expectBodyRegex,
+		Headers:      expectHeaders,
+		MaxLatency:   expectMaxLatency,
+		MinSize:      expectMinSize,
+		MaxSize:      expectMaxSize,
+	}
+	for _, expectation := range expectJSON {
+		path, value, found := strings.Cut(expectation, "=")
+		configured.JSON = append(configured.JSON, models.JSONCheck{Path: path, Value: value, Exists: !found})
+	}
+
+	if len(configured.Status) == 0 && len(configured.BodyContains) == 0 && len(configured.BodyRegex) == 0 &&
+		len(configured.JSON) == 0 && len(configured.Headers) == 0 && configured.MaxLatency == 0 &&
+		configured.MinSize == 0 && configured.MaxSize == 0 {
+		return
+	}
+
+	*checks = &configured
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"stresstest/internal/httpclient"
+	"stresstest/internal/models"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	cookieJar           bool
+	isolatedConnections bool
+
+	tlsCert       string
+	tlsKey        string
+	tlsCACert     string
+	tlsInsecure   bool
+	tlsServerName string
+	tlsMinVersion string
+	tlsMaxVersion string
+	tlsCiphers    []string
+
+	proxyURL string
+
+	dnsResolve    []string
+	dnsServer     string
+	dnsRoundRobin bool
+
+	h2c bool
+)
+
+// addClientFlags registra as flags do cliente HTTP usado pelos workers,
+// compartilhadas entre os comandos que executam testes
+func addClientFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&cookieJar, "cookies", false, "Mantém os cookies de cada worker (usuário virtual) entre as requisições")
+	flags.BoolVar(&isolatedConnections, "isolated-connections", false, "Cada worker usa as próprias conexões e sessões TLS, sem compartilhá-las")
+
+	flags.StringVar(&tlsCert, "cert", "", "Certificado PEM do cliente para TLS mútuo (requer --key)")
+	flags.StringVar(&tlsKey, "key", "", "Chave privada PEM do certificado do cliente")
+	flags.StringVar(&tlsCACert, "cacert", "", "Arquivo PEM com as autoridades certificadoras aceitas (substitui as do sistema)")
+	flags.BoolVar(&tlsInsecure, "insecure", false, "Não verifica o certificado do servidor")
+	flags.StringVar(&tlsServerName, "tls-server-name", "", "Nome enviado no SNI e usado na verificação do certificado")
+	flags.StringVar(&tlsMinVersion, "tls-min-version", "", "Versão TLS mínima: 1.0, 1.1, 1.2 ou 1.3")
+	flags.StringVar(&tlsMaxVersion, "tls-max-version", "", "Versão TLS máxima: 1.0, 1.1, 1.2 ou 1.3")
+	flags.StringSliceVar(&tlsCiphers, "tls-ciphers", nil, "Cifras permitidas no TLS 1.2 e anteriores, separadas por vírgula")
+
+	flags.StringVar(&proxyURL, "proxy", "", "Proxy http://, https:// ou socks5:// (com usuário:senha@ opcional); respeita NO_PROXY")
+
+	flags.StringArrayVar(&dnsResolve, "resolve", nil, "Fixa o endereço de um destino, no formato host:porta:endereço (pode ser repetida)")
+	flags.StringVar(&dnsServer, "dns-server", "", "Servidor DNS (host[:porta]) usado no lugar do do sistema")
+	flags.BoolVar(&dnsRoundRobin, "dns-round-robin", false, "Distribui as novas conexões entre todos os endereços A/AAAA resolvidos")
+
+	flags.BoolVar(&h2c, "h2c", false, "Usa HTTP/2 sem TLS (prior knowledge) nas URLs http://; as https:// passam a exigir HTTP/2")
+}
+
+// applyClientOptions aplica as opções do cliente informadas nas flags,
+// preservando as já definidas (por exemplo, no cenário)
+func applyClientOptions(options *models.ClientOptions) error {
+	if cookieJar {
+		options.CookieJar = true
+	}
+	if isolatedConnections {
+		options.IsolatedConnections = true
+	}
+
+	tls := &options.TLS
+	setIfNotEmpty(&tls.CertFile, tlsCert)
+	setIfNotEmpty(&tls.KeyFile, tlsKey)
+	setIfNotEmpty(&tls.CAFile, tlsCACert)
+	setIfNotEmpty(&tls.ServerName, tlsServerName)
+	setIfNotEmpty(&tls.MinVersion, tlsMinVersion)
+	setIfNotEmpty(&tls.MaxVersion, tlsMaxVersion)
+	if tlsInsecure {
+		tls.Insecure = true
+	}
+	if len(tlsCiphers) > 0 {
+		tls.CipherSuites = tlsCiphers
+	}
+
+	setIfNotEmpty(&options.Proxy, proxyURL)
+
+	options.DNS.Resolve = append(options.DNS.Resolve, dnsResolve...)
+	setIfNotEmpty(&options.DNS.Server, dnsServer)
+	if dnsRoundRobin {
+		options.DNS.RoundRobin = true
+	}
+	if h2c {
+		options.H2C = true
+	}
+
+	// Valida a configuração antes do teste, carregando certificados e chaves
+	// e interpretando a URL do proxy e as resoluções fixas
+	if _, err := httpclient.NewTransport(*options); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setIfNotEmpty substitui o valor de destino quando a flag foi informada
+func setIfNotEmpty(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
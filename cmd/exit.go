@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Códigos de saída do processo, para uso em pipelines
+const (
+	// exitFailure indica um erro durante a execução
+	exitFailure = 1
+	// exitInvalidParameters indica parâmetros, flags ou cenário inválidos
+	exitInvalidParameters = 2
+	// exitThresholdsFailed indica que o teste terminou com limites reprovados
+	exitThresholdsFailed = 3
+	// exitInterrupted indica que o teste ou a busca de capacidade foi
+	// interrompido (Ctrl+C ou SIGTERM) antes de concluir, com um resultado parcial
+	exitInterrupted = 4
+)
+
+// exitError associa um erro ao código de saída do processo
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// invalidParameters marca um erro de validação da entrada
+func invalidParameters(prefix string, err error) error {
+	return &exitError{code: exitInvalidParameters, err: fmt.Errorf("%s: %w", prefix, err)}
+}
+
+// interrupted marca a interrupção de uma execução pelo usuário
+func interrupted(err error) error {
+	return &exitError{code: exitInterrupted, err: err}
+}
+
+// exitCode retorna o código de saída correspondente ao erro
+func exitCode(err error) int {
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return exitFailure
+}
+
+// exit encerra o processo com o código correspondente ao erro
+func exit(err error) {
+	os.Exit(exitCode(err))
+}
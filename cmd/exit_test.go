@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "erro de execução", err: errors.New("falha de rede"), code: exitFailure},
+		{name: "parâmetros inválidos", err: invalidParameters("parâmetros inválidos", errors.New("URL é obrigatória")), code: exitInvalidParameters},
+		{name: "limites reprovados", err: &exitError{code: exitThresholdsFailed, err: errors.New("reprovado")}, code: exitThresholdsFailed},
+		{name: "interrompido", err: interrupted(errors.New("teste interrompido")), code: exitInterrupted},
+		{name: "encapsulado", err: fmt.Errorf("contexto: %w", interrupted(errors.New("teste interrompido"))), code: exitInterrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := exitCode(tt.err); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+		})
+	}
+
+	if err := invalidParameters("parâmetros inválidos", errors.New("URL é obrigatória")); err.Error() != "parâmetros inválidos: URL é obrigatória" {
+		t.Errorf("Expected the prefixed message, got %q", err.Error())
+	}
+}
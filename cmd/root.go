@@ -3,22 +3,53 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"stresstest/internal/models"
 	"stresstest/internal/report"
+	"stresstest/internal/scenario"
 	"stresstest/internal/stresstest"
+	"stresstest/internal/threshold"
+	"stresstest/internal/version"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	targetURL   string
 	requests    int
 	concurrency int
+	duration    time.Duration
+	rate        float64
+	stagesSpec  string
+
+	method      string
+	headers     []string
+	body        string
+	bodyFile    string
+	contentType string
+
+	requestsFile  string
+	requestsOrder string
+
+	feederFiles     []string
+	feederStrategy  string
+	feederExhausted string
+
+	timeSeriesFile string
+	outputFormat   string
+	outputFile     string
+	resultsFile    string
+	resultsFormat  string
+	htmlFile       string
 )
 
 // rootCmd representa o comando base quando chamado sem subcomandos
@@ -30,117 +61,519 @@ para realizar testes de carga em serviços web.
 
 A ferramenta permite especificar:
 - URL do serviço a ser testado
-- Número total de requisições
-- Nível de concorrência (requisições simultâneas)
+- Número total de requisições ou duração do teste
+- Nível de concorrência (requisições simultâneas) ou perfil de carga em estágios
 
-Exemplo de uso:
-  stresstest --url=http://google.com --requests=1000 --concurrency=10`,
-	RunE: runStressTest,
+Exemplos de uso:
+  stresstest --url=http://google.com --requests=1000 --concurrency=10
+  stresstest --url=http://google.com --duration=10m --concurrency=50
+  stresstest --url=http://google.com --duration=5m --rate=200 --concurrency=500
+  stresstest --url=http://google.com --stages=2m:200,10m:200,0s:1000,30s:1000,1m:0
+  stresstest --url=http://localhost:8080/api/orders --method=POST --content-type=application/json \
+    --header="Authorization: Bearer abc" --body-file=order.json --requests=1000 --concurrency=20`,
+	RunE:    runStressTest,
+	Version: version.Version,
 }
 
 // Execute adiciona todos os comandos filhos ao comando root e configura as flags adequadamente
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		exit(err)
 	}
 }
 
 func init() {
+	// Flags desconhecidas ou com valores inválidos são erros de parâmetros
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitError{code: exitInvalidParameters, err: err}
+	})
+
 	// Flags obrigatórias
-	rootCmd.Flags().StringVar(&targetURL, "url", "", "URL do serviço a ser testado (obrigatório)")
-	rootCmd.Flags().IntVar(&requests, "requests", 0, "Número total de requisições (obrigatório)")
-	rootCmd.Flags().IntVar(&concurrency, "concurrency", 0, "Número de requisições simultâneas (obrigatório)")
+	rootCmd.Flags().StringVar(&targetURL, "url", "", "URL do serviço a ser testado (obrigatório sem --requests-file)")
+	rootCmd.Flags().IntVar(&requests, "requests", 0, "Número total de requisições (obrigatório sem --duration)")
+	rootCmd.Flags().IntVar(&concurrency, "concurrency", 0, "Número de requisições simultâneas (obrigatório sem --stages); com --rate, limite de requisições em andamento")
 
-	// Marca as flags como obrigatórias
-	rootCmd.MarkFlagRequired("url")
-	rootCmd.MarkFlagRequired("requests")
-	rootCmd.MarkFlagRequired("concurrency")
+	// Flags da requisição e do cliente HTTP
+	addRequestFlags(rootCmd.Flags())
+	addClientFlags(rootCmd.Flags())
+	addAuthFlags(rootCmd.Flags())
+	addCheckFlags(rootCmd.Flags())
+
+	// Flags de saída e limites
+	addOutputFlags(rootCmd.Flags())
+	addThresholdFlags(rootCmd.Flags())
+
+	// Flags opcionais
+	rootCmd.Flags().DurationVar(&duration, "duration", 0, "Duração do teste (ex: 30s, 10m); com --requests, encerra no que ocorrer primeiro")
+	rootCmd.Flags().Float64Var(&rate, "rate", 0, "Taxa constante de requisições por segundo (modo aberto, independente do tempo de resposta)")
+	rootCmd.Flags().StringVar(&stagesSpec, "stages", "", "Perfil de carga em estágios duração:usuários separados por vírgula (ex: 2m:200,10m:200,1m:0)")
 }
 
 // runStressTest executa o teste de carga principal
 func runStressTest(cmd *cobra.Command, args []string) error {
 	// Valida os parâmetros de entrada
-	if err := validateParameters(); err != nil {
-		return fmt.Errorf("parâmetros inválidos: %w", err)
+	stages, err := parseStages(stagesSpec)
+	if err != nil {
+		return invalidParameters("parâmetros inválidos", err)
 	}
 
-	// Cria o contexto com cancelamento para permitir interrupção graceful
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Configura o handler para capturar sinais de interrupção (Ctrl+C)
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-signalChan
-		fmt.Println("\n\n🛑 Interrupção detectada. Finalizando teste...")
-		cancel()
-	}()
+	if err := validateTarget(); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
 
 	// Configura o teste
 	config := models.TestConfig{
 		URL:         targetURL,
 		Requests:    requests,
 		Concurrency: concurrency,
+		Duration:    duration,
+		Rate:        rate,
+		Stages:      stages,
 	}
 
+	if err := applyRequestOptions(&config); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	if err := applyClientOptions(&config.Client); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	if err := applyAuthOptions(&config.Auth); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	applyCheckOptions(&config.Checks)
+	config.Thresholds = append(config.Thresholds, thresholdExpressions...)
+
+	if err := validateLoad(config); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	return executeTest(cmd, config)
+}
+
+// executeTest executa o teste configurado, avalia os limites, exibe o
+// relatório e grava as saídas solicitadas. Limites reprovados resultam em um
+// erro com código de saída próprio, depois de o relatório ser exibido
+func executeTest(cmd *cobra.Command, config models.TestConfig) error {
+	thresholds, err := threshold.ParseAll(config.Thresholds)
+	if err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+	if outputFormat != outputText && outputFormat != outputJSON {
+		return invalidParameters("parâmetros inválidos", fmt.Errorf("formato de saída %q desconhecido: use text ou json", outputFormat))
+	}
+	if resultsFormat != "" && resultsFormat != models.ResultsCSV && resultsFormat != models.ResultsJSONL {
+		return invalidParameters("parâmetros inválidos", fmt.Errorf("formato de resultados %q desconhecido: use csv ou jsonl", resultsFormat))
+	}
+	config.ResultsFile = resultsFile
+	config.ResultsFormat = resultsFormat
+
+	// Os erros a partir daqui não são de uso: a ajuda do comando não é exibida
+	cmd.SilenceUsage = true
+
+	jsonOnStdout := stdoutIsJSON()
+	messages := messageWriter()
+
+	ctx, cancel := interruptibleContext(messages)
+	defer cancel()
+
 	// Executa o teste
 	executor := stresstest.NewExecutor()
+	executor.SetOutput(messages)
+	metadata := report.Metadata{StartedAt: time.Now()}
 	result, err := executor.Run(ctx, config)
 	if err != nil {
 		return fmt.Errorf("erro durante a execução do teste: %w", err)
 	}
+	metadata.FinishedAt = time.Now()
+	result.Thresholds = threshold.Evaluate(result.Report, thresholds)
 
 	// Exibe o relatório
-	formatter := report.NewFormatter()
-	formatter.PrintReport(result)
+	if jsonOnStdout {
+		if err := report.WriteJSON(os.Stdout, result, metadata); err != nil {
+			return fmt.Errorf("erro ao escrever o relatório JSON: %w", err)
+		}
+	} else {
+		formatter := report.NewFormatter()
+		formatter.PrintReport(result)
+	}
+
+	if outputFile != "" {
+		if err := writeReport(outputFile, result, metadata); err != nil {
+			return fmt.Errorf("erro ao gravar o relatório: %w", err)
+		}
+		fmt.Fprintf(messages, "📁 Relatório gravado em %s\n", outputFile)
+	}
+
+	if htmlFile != "" {
+		if err := writeHTML(htmlFile, result, metadata); err != nil {
+			return fmt.Errorf("erro ao gravar o relatório HTML: %w", err)
+		}
+		fmt.Fprintf(messages, "📁 Relatório HTML gravado em %s\n", htmlFile)
+	}
+
+	if resultsFile != "" {
+		if result.ResultsFileError != nil {
+			fmt.Fprintf(messages, "⚠️  Falha ao gravar os resultados de cada requisição em %s (arquivo incompleto): %v\n", resultsFile, result.ResultsFileError)
+		} else {
+			fmt.Fprintf(messages, "📁 Resultados de cada requisição gravados em %s\n", resultsFile)
+		}
+	}
+
+	if timeSeriesFile != "" {
+		if err := writeTimeSeries(timeSeriesFile, result.Report.TimeSeries); err != nil {
+			return fmt.Errorf("erro ao exportar a série temporal: %w", err)
+		}
+		fmt.Fprintf(messages, "📁 Série temporal exportada para %s\n", timeSeriesFile)
+	}
+
+	// Um teste interrompido não é aprovado, mesmo que os dados parciais
+	// atendam aos limites
+	if ctx.Err() != nil {
+		return interrupted(fmt.Errorf("teste interrompido: o relatório é parcial"))
+	}
+
+	if !threshold.Passed(result.Thresholds) {
+		return &exitError{code: exitThresholdsFailed, err: fmt.Errorf("o teste não atendeu aos limites configurados")}
+	}
 
 	return nil
 }
 
-// validateParameters valida os parâmetros de entrada
-func validateParameters() error {
-	// Valida URL
-	if targetURL == "" {
-		return fmt.Errorf("URL é obrigatória")
+// writeReport grava o relatório do teste em um arquivo, no formato de --output
+func writeReport(path string, result *models.StressTestResult, metadata report.Metadata) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
 	}
 
-	parsedURL, err := url.Parse(targetURL)
+	if outputFormat == outputJSON {
+		err = report.WriteJSON(file, result, metadata)
+	} else {
+		formatter := report.NewFormatter()
+		formatter.SetOutput(file)
+		formatter.PrintReport(result)
+	}
 	if err != nil {
-		return fmt.Errorf("URL inválida: %w", err)
+		file.Close()
+		return err
 	}
 
-	if parsedURL.Scheme == "" {
-		return fmt.Errorf("URL deve incluir o esquema (http:// ou https://)")
+	return file.Close()
+}
+
+// writeHTML grava o relatório do teste como uma página HTML
+func writeHTML(path string, result *models.StressTestResult, metadata report.Metadata) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := report.WriteHTML(file, result, metadata); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// writeTimeSeries grava a série temporal do teste em um arquivo CSV
+func writeTimeSeries(path string, series []models.IntervalMetrics) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := report.WriteTimeSeriesCSV(file, series); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// stdoutIsJSON indica que o relatório JSON é escrito na saída padrão
+func stdoutIsJSON() bool {
+	return outputFormat == outputJSON && outputFile == ""
+}
+
+// messageWriter retorna onde são escritos o progresso e as mensagens. Com o
+// JSON na saída padrão, eles vão para a saída de erros, para que a saída
+// padrão contenha apenas o documento
+func messageWriter() io.Writer {
+	if stdoutIsJSON() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// interruptibleContext cria o contexto com cancelamento para permitir
+// interrupção graceful ao receber Ctrl+C ou SIGTERM; o aviso é escrito em out
+func interruptibleContext(out io.Writer) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Configura o handler para capturar sinais de interrupção (Ctrl+C)
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signalChan:
+			fmt.Fprintln(out, "\n\n🛑 Interrupção detectada. Finalizando teste...")
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(signalChan)
+	}()
+
+	return ctx, cancel
+}
+
+// validateLoad valida os parâmetros de carga do teste
+func validateLoad(config models.TestConfig) error {
+	// Valida o perfil em estágios, que substitui a duração e a concorrência
+	if len(config.Stages) > 0 {
+		if config.Duration > 0 {
+			return fmt.Errorf("duração não pode ser usada com estágios (a duração é a soma dos estágios)")
+		}
+		if config.Rate > 0 {
+			return fmt.Errorf("taxa constante não pode ser usada com estágios")
+		}
+		if config.Requests < 0 || config.Requests > 1000000 {
+			return fmt.Errorf("número de requisições deve estar entre 0 e 1.000.000")
+		}
+		if err := validateStages(config.Stages); err != nil {
+			return err
+		}
+		return validateFeeders(config.Feeders, stresstest.MaxStageTarget(config.Stages))
 	}
 
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return fmt.Errorf("apenas esquemas HTTP e HTTPS são suportados")
+	// Valida número de requisições e duração
+	if config.Duration < 0 {
+		return fmt.Errorf("duração não pode ser negativa")
 	}
 
-	// Valida número de requisições
-	if requests <= 0 {
-		return fmt.Errorf("número de requisições deve ser maior que 0")
+	if config.Requests < 0 || (config.Requests == 0 && config.Duration == 0) {
+		return fmt.Errorf("número de requisições deve ser maior que 0 (ou informe a duração)")
 	}
 
-	if requests > 1000000 {
+	if config.Requests > 1000000 {
 		return fmt.Errorf("número de requisições não pode exceder 1.000.000")
 	}
 
 	// Valida concorrência
-	if concurrency <= 0 {
+	if config.Concurrency <= 0 {
 		return fmt.Errorf("nível de concorrência deve ser maior que 0")
 	}
 
-	if concurrency > 10000 {
+	if config.Concurrency > 10000 {
 		return fmt.Errorf("nível de concorrência não pode exceder 10.000")
 	}
 
-	if concurrency > requests {
+	// Valida a taxa do modo aberto
+	if config.Rate < 0 {
+		return fmt.Errorf("taxa não pode ser negativa")
+	}
+
+	if config.Rate > 100000 {
+		return fmt.Errorf("taxa não pode exceder 100.000 req/s")
+	}
+
+	if config.Rate == 0 && config.Requests > 0 && config.Duration == 0 && config.Concurrency > config.Requests {
 		return fmt.Errorf("nível de concorrência não pode ser maior que o número total de requisições")
 	}
 
+	return validateFeeders(config.Feeders, config.Concurrency)
+}
+
+// validateFeeders verifica se as fontes de dados com registro exclusivo por
+// worker e sem reaproveitamento têm registros para todos os workers
+func validateFeeders(feeders []models.Feeder, workers int) error {
+	for _, feeder := range feeders {
+		if feeder.Strategy == models.FeedUnique && feeder.OnExhausted == models.ExhaustedStop && workers > len(feeder.Records) {
+			return fmt.Errorf("%s tem %d registros para %d workers: com a estratégia %q cada worker precisa de um registro exclusivo",
+				feeder.Name, len(feeder.Records), workers, models.FeedUnique)
+		}
+	}
+	return nil
+}
+
+// validateStages valida os limites do perfil de carga em estágios
+func validateStages(stages []models.Stage) error {
+	var total time.Duration
+	for i, stage := range stages {
+		if stage.Duration < 0 {
+			return fmt.Errorf("duração do estágio %d não pode ser negativa", i+1)
+		}
+		if stage.Target < 0 || stage.Target > 10000 {
+			return fmt.Errorf("número de usuários do estágio %d deve estar entre 0 e 10.000", i+1)
+		}
+		total += stage.Duration
+	}
+
+	if total == 0 {
+		return fmt.Errorf("a duração total dos estágios deve ser maior que 0")
+	}
+
+	return nil
+}
+
+// validateTarget valida a origem das requisições: a URL ou o arquivo de requisições
+func validateTarget() error {
+	if requestsFile == "" {
+		return scenario.ValidateURL(targetURL)
+	}
+
+	if targetURL != "" {
+		return fmt.Errorf("--url não pode ser usado com --requests-file")
+	}
+
+	if requestsOrder != models.OrderSequential && requestsOrder != models.OrderRandom {
+		return fmt.Errorf("ordem das requisições deve ser %q ou %q", models.OrderSequential, models.OrderRandom)
+	}
+
+	return nil
+}
+
+// Formatos aceitos em --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// addOutputFlags registra as flags que controlam as saídas do relatório,
+// compartilhadas entre os comandos que executam testes
+func addOutputFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&timeSeriesFile, "timeseries-file", "", "Exporta as métricas por segundo (série temporal) para um arquivo CSV")
+	flags.StringVar(&outputFormat, "output", outputText, "Formato do relatório: text ou json (esquema versionado, para pipelines)")
+	flags.StringVar(&htmlFile, "html", "", "Grava o relatório como uma página HTML independente, com gráficos interativos, neste arquivo")
+	flags.StringVar(&resultsFile, "results-file", "", "Grava cada requisição (instante, worker, nome, status, duração, fases, tamanho e erro) neste arquivo durante o teste")
+	flags.StringVar(&resultsFormat, "results-format", "", "Formato de --results-file: csv ou jsonl (padrão: deduzido pela extensão, csv sem .jsonl)")
+	flags.StringVar(&outputFile, "output-file", "", "Grava o relatório no formato de --output neste arquivo; o relatório em texto continua na saída padrão")
+}
+
+// addRequestFlags registra as flags que descrevem a requisição enviada,
+// compartilhadas entre os comandos que executam testes
+func addRequestFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&method, "method", "GET", "Método HTTP das requisições")
+	flags.StringArrayVar(&headers, "header", nil, "Cabeçalho no formato 'Nome: valor' (pode ser repetido)")
+	flags.StringVar(&body, "body", "", "Corpo enviado em cada requisição")
+	flags.StringVar(&bodyFile, "body-file", "", "Arquivo com o corpo enviado em cada requisição")
+	flags.StringVar(&contentType, "content-type", "", "Valor do cabeçalho Content-Type")
+	flags.StringVar(&requestsFile, "requests-file", "", "Arquivo JSONL com as requisições (method, url, headers, body, weight) usado no lugar de --url")
+	flags.StringVar(&requestsOrder, "requests-order", models.OrderSequential, "Ordem de uso das requisições do arquivo: sequential ou random (respeitando os pesos)")
+	flags.StringArrayVar(&feederFiles, "feeder", nil, "Arquivo CSV ou JSON cujas colunas viram variáveis dos templates (pode ser repetido)")
+	flags.StringVar(&feederStrategy, "feeder-strategy", models.FeedSequential, "Leitura dos registros: sequential, random ou unique (um registro exclusivo por worker)")
+	flags.StringVar(&feederExhausted, "feeder-exhausted", models.ExhaustedRecycle, "Ao esgotar os registros: recycle (recomeça) ou stop (encerra o teste)")
+}
+
+// applyRequestOptions preenche a configuração com a descrição das requisições
+// informada nas flags: método, cabeçalhos, corpo, o arquivo de requisições e
+// as fontes de dados
+func applyRequestOptions(config *models.TestConfig) error {
+	if !isValidMethod(method) {
+		return fmt.Errorf("método HTTP inválido: %q", method)
+	}
+	config.Method = strings.ToUpper(method)
+
+	requestHeaders, err := parseHeaders(headers)
+	if err != nil {
+		return err
+	}
+
+	if contentType != "" {
+		requestHeaders.Set("Content-Type", contentType)
+	}
+	config.Headers = requestHeaders
+
+	if body != "" && bodyFile != "" {
+		return fmt.Errorf("--body e --body-file não podem ser usados juntos")
+	}
+
+	config.Body = []byte(body)
+	if bodyFile != "" {
+		config.Body, err = os.ReadFile(bodyFile)
+		if err != nil {
+			return fmt.Errorf("erro ao ler o corpo da requisição: %w", err)
+		}
+	}
+
+	if requestsFile != "" {
+		config.Targets, err = scenario.LoadRequestsFile(requestsFile)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar o arquivo de requisições: %w", err)
+		}
+		config.TargetOrder = requestsOrder
+	}
+
+	for _, path := range feederFiles {
+		feeder, err := scenario.LoadFeeder(path, feederStrategy, feederExhausted)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar os dados: %w", err)
+		}
+		config.Feeders = append(config.Feeders, feeder)
+	}
+
 	return nil
 }
+
+// parseHeaders interpreta cabeçalhos no formato "Nome: valor"
+func parseHeaders(values []string) (http.Header, error) {
+	parsed := make(http.Header)
+	for _, value := range values {
+		name, content, found := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("cabeçalho inválido %q: use o formato 'Nome: valor'", value)
+		}
+		parsed.Add(name, strings.TrimSpace(content))
+	}
+	return parsed, nil
+}
+
+// isValidMethod verifica se o método HTTP contém apenas caracteres permitidos
+func isValidMethod(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if !strings.ContainsRune("!#$%&'*+-.^_`|~", r) &&
+			!(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStages interpreta o perfil de carga no formato duração:usuários
+// separados por vírgula, por exemplo "2m:200,10m:200,0s:1000,30s:1000,1m:0"
+func parseStages(spec string) ([]models.Stage, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	var stages []models.Stage
+	for _, part := range strings.Split(spec, ",") {
+		durationText, targetText, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found {
+			return nil, fmt.Errorf("estágio inválido %q: use o formato duração:usuários", part)
+		}
+
+		stageDuration, err := time.ParseDuration(durationText)
+		if err != nil || stageDuration < 0 {
+			return nil, fmt.Errorf("duração inválida no estágio %q", part)
+		}
+
+		target, err := strconv.Atoi(targetText)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("número de usuários inválido no estágio %q", part)
+		}
+
+		stages = append(stages, models.Stage{Duration: stageDuration, Target: target})
+	}
+
+	return stages, nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stresstest/internal/models"
+
+	"github.com/spf13/pflag"
+)
+
+// resetFlags devolve as flags do comando principal aos valores padrão
+func resetFlags(t *testing.T) {
+	t.Helper()
+	rootCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		var err error
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			err = slice.Replace(nil)
+		} else {
+			err = flag.Value.Set(flag.DefValue)
+		}
+		if err != nil {
+			t.Fatalf("Expected no error resetting --%s, got %v", flag.Name, err)
+		}
+		flag.Changed = false
+	})
+}
+
+// parseFlags interpreta os argumentos a partir das flags padrão, que são
+// restauradas ao fim do teste
+func parseFlags(t *testing.T, args ...string) {
+	t.Helper()
+	resetFlags(t)
+	t.Cleanup(func() { resetFlags(t) })
+	if err := rootCmd.Flags().Parse(args); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	parsed, err := parseHeaders([]string{
+		"Authorization: Bearer abc",
+		"x-trace:  1  ",
+		"Accept: text/html",
+		"accept: application/json",
+		"X-Url: http://exemplo.com:8080/a",
+		"X-Vazio:",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := http.Header{
+		"Authorization": {"Bearer abc"},
+		"X-Trace":       {"1"},
+		// Cabeçalhos repetidos mantêm todos os valores, na ordem informada
+		"Accept":  {"text/html", "application/json"},
+		"X-Url":   {"http://exemplo.com:8080/a"},
+		"X-Vazio": {""},
+	}
+	if len(parsed) != len(expected) {
+		t.Errorf("Expected %v, got %v", expected, parsed)
+	}
+	for name, values := range expected {
+		if strings.Join(parsed.Values(name), "|") != strings.Join(values, "|") {
+			t.Errorf("Expected %s to be %q, got %q", name, values, parsed.Values(name))
+		}
+	}
+
+	for _, value := range []string{"Authorization Bearer abc", ": valor", "  : valor", "X Trace: 1"} {
+		if _, err := parseHeaders([]string{value}); err == nil {
+			t.Errorf("Expected an error for the header %q", value)
+		}
+	}
+}
+
+func TestIsValidMethod(t *testing.T) {
+	for _, value := range []string{"GET", "post", "PURGE", "M-SEARCH", "X_CUSTOM"} {
+		if !isValidMethod(value) {
+			t.Errorf("Expected %q to be a valid method", value)
+		}
+	}
+	for _, value := range []string{"", "GET POST", "GÉT", "GET\n", "(GET)", "A/B"} {
+		if isValidMethod(value) {
+			t.Errorf("Expected %q to be an invalid method", value)
+		}
+	}
+}
+
+func TestApplyRequestOptions(t *testing.T) {
+	bodyPath := filepath.Join(t.TempDir(), "pedido.json")
+	if err := os.WriteFile(bodyPath, []byte(`{"item": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		method      string
+		body        string
+		contentType string
+		err         string
+	}{
+		{name: "padrão", method: "GET"},
+		{
+			name:        "corpo informado",
+			args:        []string{"--method=post", "--body=a=1", "--content-type=application/x-www-form-urlencoded"},
+			method:      "POST",
+			body:        "a=1",
+			contentType: "application/x-www-form-urlencoded",
+		},
+		{
+			name:        "corpo de arquivo",
+			args:        []string{"--method=PUT", "--body-file=" + bodyPath, "--header=Content-Type: text/plain", "--content-type=application/json"},
+			method:      "PUT",
+			body:        `{"item": 1}`,
+			contentType: "application/json",
+		},
+		{name: "método inválido", args: []string{"--method=GET POST"}, err: `método HTTP inválido: "GET POST"`},
+		{name: "cabeçalho inválido", args: []string{"--header=Authorization Bearer abc"}, err: "cabeçalho inválido"},
+		{name: "corpo duplicado", args: []string{"--body=x", "--body-file=" + bodyPath}, err: "não podem ser usados juntos"},
+		{name: "arquivo ausente", args: []string{"--body-file=" + bodyPath + ".x"}, err: "erro ao ler o corpo da requisição"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseFlags(t, tt.args...)
+
+			var config models.TestConfig
+			err := applyRequestOptions(&config)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if config.Method != tt.method {
+				t.Errorf("Expected method %s, got %s", tt.method, config.Method)
+			}
+			if string(config.Body) != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, config.Body)
+			}
+			// --content-type substitui o cabeçalho informado com --header
+			if got := config.Headers.Values("Content-Type"); strings.Join(got, "|") != tt.contentType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.contentType, got)
+			}
+		})
+	}
+}
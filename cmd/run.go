@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"stresstest/internal/scenario"
+
+	"github.com/spf13/cobra"
+)
+
+var scenarioFile string
+
+// runCmd executa um teste descrito em um arquivo de cenário
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Executa um cenário de teste descrito em um arquivo YAML ou JSON",
+	Long: `Executa um cenário com várias requisições nomeadas e pesos, descrito em um
+arquivo YAML ou JSON. A cada iteração a requisição é escolhida de acordo com o
+mix de pesos e o relatório exibe as estatísticas de cada requisição.
+
+Exemplo de cenário:
+  name: loja
+  load:
+    concurrency: 50
+    duration: 5m
+  headers:
+    Authorization: Bearer abc
+  requests:
+    - name: home
+      url: https://loja.exemplo.com/
+      weight: 6
+    - name: checkout
+      method: POST
+      url: https://loja.exemplo.com/api/checkout
+      headers:
+        Content-Type: application/json
+      body:
+        item: 42
+      weight: 1
+
+Exemplo de uso:
+  stresstest run -f scenario.yaml`,
+	RunE: runScenario,
+}
+
+func init() {
+	runCmd.Flags().StringVarP(&scenarioFile, "file", "f", "", "Arquivo de cenário YAML ou JSON (obrigatório)")
+	addClientFlags(runCmd.Flags())
+	addAuthFlags(runCmd.Flags())
+	addCheckFlags(runCmd.Flags())
+	addThresholdFlags(runCmd.Flags())
+	addOutputFlags(runCmd.Flags())
+
+	runCmd.MarkFlagRequired("file")
+
+	rootCmd.AddCommand(runCmd)
+}
+
+// runScenario carrega o cenário e executa o teste
+func runScenario(cmd *cobra.Command, args []string) error {
+	loaded, err := scenario.LoadFile(scenarioFile)
+	if err != nil {
+		return invalidParameters("erro ao carregar o cenário", err)
+	}
+
+	config, err := loaded.Config()
+	if err != nil {
+		return invalidParameters("cenário inválido", err)
+	}
+
+	if err := applyClientOptions(&config.Client); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	if err := applyAuthOptions(&config.Auth); err != nil {
+		return invalidParameters("parâmetros inválidos", err)
+	}
+
+	applyCheckOptions(&config.Checks)
+	config.Thresholds = append(config.Thresholds, thresholdExpressions...)
+
+	if err := validateLoad(config); err != nil {
+		return invalidParameters("cenário inválido", err)
+	}
+
+	if loaded.Name != "" {
+		fmt.Fprintf(messageWriter(), "Cenário: %s\n", loaded.Name)
+	}
+
+	return executeTest(cmd, config)
+}
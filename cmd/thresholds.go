@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"strings"
+
+	"stresstest/internal/threshold"
+
+	"github.com/spf13/pflag"
+)
+
+var thresholdExpressions []string
+
+// addThresholdFlags registra as flags dos limites (SLOs) que aprovam ou
+// reprovam o teste, compartilhadas entre os comandos que executam testes
+func addThresholdFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(&thresholdExpressions, "threshold", nil,
+		"Limite avaliado ao fim do teste, como p95<250ms, error_rate<1% ou rps>500 (pode ser repetida); "+
+			"reprovado, o processo termina com código 3. Métricas: "+strings.Join(threshold.Names(), ", "))
+}
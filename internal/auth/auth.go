@@ -0,0 +1,73 @@
+// Package auth implementa a autenticação aplicada às requisições do teste:
+// credenciais estáticas (bearer e basic) e o fluxo OAuth2 client credentials,
+// com o token renovado em segundo plano antes de expirar
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"stresstest/internal/models"
+)
+
+// Provider aplica as credenciais às requisições do teste. Apply é chamado
+// concorrentemente pelos workers e nunca bloqueia
+type Provider interface {
+	// Start prepara as credenciais antes do teste e as mantém atualizadas até
+	// o fim de ctx
+	Start(ctx context.Context) error
+	// Apply adiciona as credenciais aos cabeçalhos da requisição
+	Apply(header http.Header)
+	// Report retorna as estatísticas das requisições de obtenção de token;
+	// nil quando o provedor não as faz
+	Report() *models.GroupReport
+}
+
+// New cria o provedor descrito pelas opções; retorna nil sem autenticação.
+// client é usado nas requisições ao endpoint de tokens
+func New(options models.AuthOptions, client *http.Client) (Provider, error) {
+	switch options.Type {
+	case "":
+		return nil, nil
+	case models.AuthBearer:
+		if options.Token == "" {
+			return nil, fmt.Errorf("token bearer vazio")
+		}
+		return static("Bearer " + options.Token), nil
+	case models.AuthBasic:
+		if options.Username == "" {
+			return nil, fmt.Errorf("usuário da autenticação basic vazio")
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(options.Username + ":" + options.Password))
+		return static("Basic " + credentials), nil
+	case models.AuthOAuth2:
+		return newClientCredentials(options, client)
+	default:
+		return nil, fmt.Errorf("tipo de autenticação inválido %q", options.Type)
+	}
+}
+
+// Describe retorna uma descrição da autenticação para o cabeçalho do teste
+func Describe(options models.AuthOptions) string {
+	switch options.Type {
+	case models.AuthBasic:
+		return fmt.Sprintf("basic (%s)", options.Username)
+	case models.AuthOAuth2:
+		return fmt.Sprintf("OAuth2 client credentials (%s)", options.TokenURL)
+	default:
+		return options.Type
+	}
+}
+
+// static aplica um valor fixo ao cabeçalho Authorization
+type static string
+
+func (s static) Start(context.Context) error { return nil }
+
+func (s static) Apply(header http.Header) {
+	header.Set("Authorization", string(s))
+}
+
+func (s static) Report() *models.GroupReport { return nil }
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestStaticProviders(t *testing.T) {
+	bearer, err := New(models.AuthOptions{Type: models.AuthBearer, Token: "abc"}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	header := make(http.Header)
+	bearer.Apply(header)
+	if got := header.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Expected 'Bearer abc', got %q", got)
+	}
+
+	basic, _ := New(models.AuthOptions{Type: models.AuthBasic, Username: "ana", Password: "s3"}, http.DefaultClient)
+	basic.Apply(header)
+	if got := header.Get("Authorization"); got != "Basic YW5hOnMz" {
+		t.Errorf("Expected 'Basic YW5hOnMz', got %q", got)
+	}
+}
+
+func TestClientCredentials(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if r.FormValue("grant_type") != "client_credentials" || id != "app" || secret != "segredo" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"t%d","token_type":"bearer","expires_in":1}`, issued.Add(1))
+	}))
+	defer server.Close()
+
+	provider, err := New(models.AuthOptions{
+		Type:         models.AuthOAuth2,
+		TokenURL:     server.URL,
+		ClientID:     "app",
+		ClientSecret: "segredo",
+		Scopes:       []string{"read", "write"},
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := provider.Start(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	header := make(http.Header)
+	provider.Apply(header)
+	if got := header.Get("Authorization"); got != "Bearer t1" {
+		t.Errorf("Expected 'Bearer t1', got %q", got)
+	}
+
+	// Com validade de 1s, o token é renovado na metade da validade
+	time.Sleep(700 * time.Millisecond)
+	provider.Apply(header)
+	if got := header.Get("Authorization"); got != "Bearer t2" {
+		t.Errorf("Expected the token to be refreshed to 'Bearer t2', got %q", got)
+	}
+
+	report := provider.Report()
+	if report.TotalRequests < 2 || report.FailedReqs != 0 {
+		t.Errorf("Expected at least 2 successful token requests, got %d (%d failed)", report.TotalRequests, report.FailedReqs)
+	}
+}
+
+func TestClientCredentialsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider, _ := New(models.AuthOptions{Type: models.AuthOAuth2, TokenURL: server.URL, ClientID: "app"}, server.Client())
+	if err := provider.Start(context.Background()); err == nil {
+		t.Error("Expected an error when the token endpoint rejects the client")
+	}
+}
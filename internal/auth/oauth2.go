@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+)
+
+const (
+	// defaultTokenLifetime é a validade assumida quando o servidor não informa expires_in
+	defaultTokenLifetime = time.Hour
+	// minRefreshMargin é a antecedência mínima da renovação em relação à expiração
+	minRefreshMargin = 5 * time.Second
+	// maxRetryInterval limita o intervalo entre tentativas de renovação com falha
+	maxRetryInterval = 30 * time.Second
+)
+
+// token é um token de acesso e a sua expiração
+type token struct {
+	header  string
+	expires time.Time
+}
+
+// clientCredentials obtém tokens com o fluxo OAuth2 client credentials. O token
+// atual é lido sem bloqueio pelos workers e substituído pela renovação, que
+// ocorre em segundo plano antes da expiração
+type clientCredentials struct {
+	options models.AuthOptions
+	client  *http.Client
+	current atomic.Pointer[token]
+
+	// mu protege as estatísticas das requisições de token
+	mu        sync.Mutex
+	summary   models.GroupReport
+	latencies *metrics.Histogram
+	startTime time.Time
+}
+
+// newClientCredentials valida as opções do fluxo client credentials
+func newClientCredentials(options models.AuthOptions, client *http.Client) (*clientCredentials, error) {
+	tokenURL, err := url.Parse(options.TokenURL)
+	if err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+		return nil, fmt.Errorf("URL do endpoint de tokens inválida %q", options.TokenURL)
+	}
+	if options.ClientID == "" {
+		return nil, fmt.Errorf("client id vazio")
+	}
+
+	return &clientCredentials{
+		options:   options,
+		client:    client,
+		summary:   models.GroupReport{Name: options.TokenURL},
+		latencies: metrics.NewHistogram(),
+	}, nil
+}
+
+// Start obtém o primeiro token, falhando se não for possível, e inicia a
+// renovação em segundo plano
+func (c *clientCredentials) Start(ctx context.Context) error {
+	c.startTime = time.Now()
+
+	current, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	c.current.Store(current)
+
+	go c.refresh(ctx)
+	return nil
+}
+
+// Apply adiciona o token atual à requisição
+func (c *clientCredentials) Apply(header http.Header) {
+	if current := c.current.Load(); current != nil {
+		header.Set("Authorization", current.header)
+	}
+}
+
+// Report retorna as estatísticas das requisições ao endpoint de tokens
+func (c *clientCredentials) Report() *models.GroupReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := c.summary
+	report.AvgResponseTime = c.latencies.Mean()
+	report.MaxResponseTime = c.latencies.Max()
+	report.Percentiles = c.latencies.Percentiles()
+	if elapsed := time.Since(c.startTime); elapsed > 0 {
+		report.RequestsPerSec = float64(report.TotalRequests) / elapsed.Seconds()
+	}
+	return &report
+}
+
+// refresh renova o token antes da expiração até o fim de ctx. Em caso de
+// falha, o token atual continua em uso e novas tentativas são feitas com
+// intervalo crescente
+func (c *clientCredentials) refresh(ctx context.Context) {
+	retry := time.Second
+	timer := time.NewTimer(refreshDelay(c.current.Load(), time.Now()))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
+		next, err := c.fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			timer.Reset(retry)
+			retry = min(retry*2, maxRetryInterval)
+			continue
+		}
+
+		retry = time.Second
+		c.current.Store(next)
+		timer.Reset(refreshDelay(next, time.Now()))
+	}
+}
+
+// refreshDelay calcula quando renovar o token: com 10% da validade restante,
+// respeitando a antecedência mínima, mas nunca antes da metade da validade
+func refreshDelay(current *token, now time.Time) time.Duration {
+	remaining := current.expires.Sub(now)
+	margin := min(max(remaining/10, minRefreshMargin), remaining/2)
+	return max(remaining-margin, 0)
+}
+
+// fetch solicita um novo token ao endpoint e registra a requisição nas estatísticas
+func (c *clientCredentials) fetch(ctx context.Context) (*token, error) {
+	start := time.Now()
+	result, err := c.request(ctx)
+	c.record(time.Since(start), err == nil)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao obter o token de acesso: %w", err)
+	}
+	return result, nil
+}
+
+// request executa a requisição client credentials (RFC 6749, seção 4.4)
+func (c *clientCredentials) request(ctx context.Context) (*token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.options.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.options.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.options.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.options.ClientID), url.QueryEscape(c.options.ClientSecret))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("endpoint de tokens respondeu HTTP %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("resposta do endpoint de tokens inválida: %w", err)
+	}
+	if payload.AccessToken == "" {
+		return nil, fmt.Errorf("resposta do endpoint de tokens sem access_token")
+	}
+
+	tokenType := payload.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	lifetime := defaultTokenLifetime
+	if payload.ExpiresIn > 0 {
+		lifetime = time.Duration(payload.ExpiresIn) * time.Second
+	}
+
+	return &token{
+		header:  tokenType + " " + payload.AccessToken,
+		expires: time.Now().Add(lifetime),
+	}, nil
+}
+
+// record registra uma requisição ao endpoint de tokens
+func (c *clientCredentials) record(duration time.Duration, success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.summary.TotalRequests++
+	if success {
+		c.summary.SuccessfulReqs++
+	} else {
+		c.summary.FailedReqs++
+	}
+	c.latencies.Record(duration)
+}
@@ -0,0 +1,242 @@
+package capacity
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// Modos de busca suportados
+const (
+	ModeConcurrency = "concurrency"
+	ModeRate        = "rate"
+)
+
+// minLevelGap é a menor diferença entre níveis de carga avaliada pela busca
+// (um usuário virtual ou uma requisição por segundo)
+const minLevelGap = 1.0
+
+// Runner executa um teste de carga; implementado por stresstest.Executor
+type Runner interface {
+	Run(ctx context.Context, config models.TestConfig) (*models.StressTestResult, error)
+}
+
+// SLO define os limites que um nível de carga deve respeitar para ser sustentável
+type SLO struct {
+	// MaxErrorRate é o percentual máximo de falhas
+	MaxErrorRate float64
+	// MaxP99 é a latência p99 máxima; zero desativa o critério
+	MaxP99 time.Duration
+}
+
+// Options configura a busca de capacidade
+type Options struct {
+	Mode string
+	// Start é o nível inicial de carga
+	Start float64
+	// Factor multiplica o nível a cada degrau enquanto o SLO é atendido
+	Factor float64
+	// Max é o nível máximo avaliado
+	Max float64
+	// Precision é a diferença relativa (%) entre o último nível aprovado e o
+	// primeiro reprovado em que a bisseção termina
+	Precision float64
+	// StepDuration é a duração de cada medição
+	StepDuration time.Duration
+	// MaxInFlight é o limite de requisições em andamento no modo de taxa
+	MaxInFlight int
+	SLO         SLO
+}
+
+// Search encontra a maior carga sustentável aumentando o nível em degraus até
+// violar o SLO e, em seguida, bissectando entre o último nível aprovado e o
+// primeiro reprovado
+type Search struct {
+	runner  Runner
+	base    models.TestConfig
+	options Options
+	onPoint func(models.CapacityPoint)
+}
+
+// NewSearch cria uma nova busca de capacidade a partir da configuração base
+func NewSearch(runner Runner, base models.TestConfig, options Options) *Search {
+	return &Search{
+		runner:  runner,
+		base:    base,
+		options: options,
+	}
+}
+
+// OnPoint registra uma função chamada após cada medição, útil para exibir o progresso
+func (s *Search) OnPoint(fn func(models.CapacityPoint)) {
+	s.onPoint = fn
+}
+
+// Run executa a busca. Em caso de interrupção retorna os pontos medidos até o
+// momento junto com o erro do contexto
+func (s *Search) Run(ctx context.Context) (*models.CapacityResult, error) {
+	result := &models.CapacityResult{Mode: s.options.Mode}
+
+	measure := func(level float64) (bool, error) {
+		point, err := s.measure(ctx, level)
+		if err != nil {
+			return false, err
+		}
+		result.Points = append(result.Points, point)
+		if s.onPoint != nil {
+			s.onPoint(point)
+		}
+		return point.Passed, nil
+	}
+
+	// Fase 1: aumenta a carga em degraus até violar o SLO ou atingir o máximo
+	good, bad := 0.0, 0.0
+	for level := s.options.Start; ; {
+		passed, err := measure(level)
+		if err != nil {
+			return s.finish(result), err
+		}
+		if !passed {
+			bad = level
+			break
+		}
+		good = level
+
+		if level >= s.options.Max {
+			break
+		}
+		level = s.round(math.Min(math.Max(level*s.options.Factor, level+minLevelGap), s.options.Max))
+	}
+
+	// Fase 2: bisseção entre o último nível aprovado e o primeiro reprovado
+	for bad > 0 && !s.converged(good, bad) {
+		mid := s.round((good + bad) / 2)
+		if mid <= good || mid >= bad {
+			break
+		}
+
+		passed, err := measure(mid)
+		if err != nil {
+			return s.finish(result), err
+		}
+		if passed {
+			good = mid
+		} else {
+			bad = mid
+		}
+	}
+
+	return s.finish(result), nil
+}
+
+// measure executa o teste em um nível de carga e avalia o SLO
+func (s *Search) measure(ctx context.Context, level float64) (models.CapacityPoint, error) {
+	config := s.base
+	config.Requests = 0
+	config.Duration = s.options.StepDuration
+	if s.options.Mode == ModeRate {
+		config.Rate = level
+		config.Concurrency = s.options.MaxInFlight
+	} else {
+		config.Rate = 0
+		config.Concurrency = int(level)
+	}
+
+	run, err := s.runner.Run(ctx, config)
+	if err != nil {
+		return models.CapacityPoint{}, fmt.Errorf("erro no nível %v: %w", level, err)
+	}
+	if ctx.Err() != nil {
+		return models.CapacityPoint{}, ctx.Err()
+	}
+
+	report := run.Report
+	point := models.CapacityPoint{
+		Level:          level,
+		RequestsPerSec: report.RequestsPerSec,
+		P99:            report.Percentiles.P99,
+	}
+
+	// Iterações descartadas no modo de taxa indicam que a carga não foi sustentada
+	attempted := report.TotalRequests + report.DroppedIterations
+	if attempted > 0 {
+		point.ErrorRate = float64(report.FailedReqs+report.DroppedIterations) / float64(attempted) * 100
+	}
+
+	point.Passed = report.TotalRequests > 0 &&
+		point.ErrorRate <= s.options.SLO.MaxErrorRate &&
+		(s.options.SLO.MaxP99 == 0 || point.P99 <= s.options.SLO.MaxP99)
+
+	return point, nil
+}
+
+// finish ordena os pontos e identifica a maior vazão sustentável e o joelho da curva
+func (s *Search) finish(result *models.CapacityResult) *models.CapacityResult {
+	sort.Slice(result.Points, func(i, j int) bool {
+		return result.Points[i].Level < result.Points[j].Level
+	})
+
+	for i := range result.Points {
+		point := &result.Points[i]
+		if point.Passed && (result.MaxSustainable == nil || point.RequestsPerSec > result.MaxSustainable.RequestsPerSec) {
+			result.MaxSustainable = point
+		}
+	}
+
+	result.Knee = knee(result.Points)
+	return result
+}
+
+// converged indica se o intervalo da bisseção atingiu a precisão configurada
+func (s *Search) converged(good, bad float64) bool {
+	gap := bad - good
+	return gap <= minLevelGap || gap/bad*100 <= s.options.Precision
+}
+
+// round ajusta o nível à granularidade do modo (concorrência é inteira)
+func (s *Search) round(level float64) float64 {
+	if s.options.Mode == ModeConcurrency {
+		return math.Round(level)
+	}
+	return math.Round(level*100) / 100
+}
+
+// knee encontra o joelho da curva nível × vazão: o ponto mais distante da reta
+// entre o primeiro e o último ponto, com ambos os eixos normalizados
+func knee(points []models.CapacityPoint) *models.CapacityPoint {
+	if len(points) < 3 {
+		return nil
+	}
+
+	first, last := points[0], points[len(points)-1]
+	levelRange := last.Level - first.Level
+	if levelRange == 0 {
+		return nil
+	}
+
+	minRPS, maxRPS := math.Inf(1), 0.0
+	for _, point := range points {
+		minRPS = math.Min(minRPS, point.RequestsPerSec)
+		maxRPS = math.Max(maxRPS, point.RequestsPerSec)
+	}
+	if maxRPS == minRPS {
+		return nil
+	}
+
+	var best *models.CapacityPoint
+	bestDistance := 0.0
+	for i := range points {
+		x := (points[i].Level - first.Level) / levelRange
+		y := (points[i].RequestsPerSec - minRPS) / (maxRPS - minRPS)
+		if distance := y - x; distance > bestDistance {
+			bestDistance = distance
+			best = &points[i]
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,92 @@
+package capacity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// fakeRunner simula um serviço que satura acima de um limite de concorrência
+type fakeRunner struct {
+	limit int
+	runs  int
+}
+
+func (r *fakeRunner) Run(ctx context.Context, config models.TestConfig) (*models.StressTestResult, error) {
+	r.runs++
+
+	report := models.TestReport{TotalRequests: 1000}
+	concurrency := config.Concurrency
+	if concurrency > r.limit {
+		report.FailedReqs = 100
+		concurrency = r.limit
+	}
+	report.RequestsPerSec = float64(concurrency) * 100
+
+	return &models.StressTestResult{Config: config, Report: report}, nil
+}
+
+func TestSearchFindsBreakingPoint(t *testing.T) {
+	runner := &fakeRunner{limit: 37}
+	search := NewSearch(runner, models.TestConfig{URL: "http://example.com"}, Options{
+		Mode:         ModeConcurrency,
+		Start:        5,
+		Factor:       2,
+		Max:          1000,
+		Precision:    1,
+		StepDuration: time.Second,
+		SLO:          SLO{MaxErrorRate: 1},
+	})
+
+	result, err := search.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.MaxSustainable == nil {
+		t.Fatal("Expected a sustainable level to be found")
+	}
+
+	if result.MaxSustainable.Level != 37 {
+		t.Errorf("Expected max sustainable level to be 37, got %v", result.MaxSustainable.Level)
+	}
+
+	if len(result.Points) != runner.runs {
+		t.Errorf("Expected %d points, got %d", runner.runs, len(result.Points))
+	}
+
+	for i := 1; i < len(result.Points); i++ {
+		if result.Points[i].Level < result.Points[i-1].Level {
+			t.Errorf("Expected points sorted by level, got %v before %v", result.Points[i-1].Level, result.Points[i].Level)
+		}
+	}
+}
+
+func TestSearchStopsAtMax(t *testing.T) {
+	runner := &fakeRunner{limit: 10000}
+	search := NewSearch(runner, models.TestConfig{}, Options{
+		Mode:         ModeConcurrency,
+		Start:        10,
+		Factor:       2,
+		Max:          50,
+		Precision:    5,
+		StepDuration: time.Second,
+		SLO:          SLO{MaxErrorRate: 1},
+	})
+
+	result, err := search.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 10, 20, 40, 50
+	if len(result.Points) != 4 {
+		t.Errorf("Expected 4 points, got %d", len(result.Points))
+	}
+
+	if result.MaxSustainable == nil || result.MaxSustainable.Level != 50 {
+		t.Errorf("Expected max sustainable level to be 50, got %+v", result.MaxSustainable)
+	}
+}
@@ -0,0 +1,163 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// dialer abre as conexões do transporte aplicando as sobrescritas de
+// endereço, o servidor DNS próprio e o rodízio entre endereços
+type dialer struct {
+	net        *net.Dialer
+	resolver   *net.Resolver
+	overrides  map[string][]string
+	roundRobin bool
+	next       atomic.Uint64
+}
+
+// newDialer cria o dialer a partir das opções de DNS, com os mesmos tempos
+// limite do transporte padrão
+func newDialer(options models.DNSOptions) (*dialer, error) {
+	overrides, err := parseResolve(options.Resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dialer{
+		net:        &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		resolver:   net.DefaultResolver,
+		overrides:  overrides,
+		roundRobin: options.RoundRobin,
+	}
+
+	if options.Server != "" {
+		server, err := dnsServerAddress(options.Server)
+		if err != nil {
+			return nil, err
+		}
+		// O resolvedor em Go consulta o servidor informado pelo mesmo
+		// protocolo (UDP ou TCP) que escolheria para o do sistema
+		d.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return d.net.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return d, nil
+}
+
+// DialContext conecta ao endereço fixado para o destino ou, sem sobrescrita,
+// aos endereços resolvidos para o host
+func (d *dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, ok := d.overrides[strings.ToLower(address)]
+	if !ok {
+		if net.ParseIP(host) != nil || !d.roundRobin && d.resolver == net.DefaultResolver {
+			return d.net.DialContext(ctx, network, address)
+		}
+		// As fases de DNS do relatório continuam medidas: a consulta recebe o
+		// contexto da requisição, com o rastreamento
+		ips, err := d.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, ip.String())
+		}
+	}
+
+	if d.roundRobin && len(addrs) > 1 {
+		start := int(d.next.Add(1)-1) % len(addrs)
+		addrs = append(addrs[start:len(addrs):len(addrs)], addrs[:start]...)
+	}
+
+	// Tenta os endereços em ordem, como o dialer padrão, até um responder
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := d.net.DialContext(ctx, network, net.JoinHostPort(addr, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// parseResolve interpreta as sobrescritas "host:porta:endereço[,endereço...]",
+// indexadas por "host:porta". Endereços IPv6 podem vir entre colchetes
+func parseResolve(entries []string) (map[string][]string, error) {
+	overrides := make(map[string][]string, len(entries))
+
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("resolução inválida %q: use host:porta:endereço", entry)
+		}
+		if port, err := strconv.Atoi(parts[1]); err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("porta inválida na resolução %q", entry)
+		}
+
+		var addrs []string
+		for _, addr := range strings.Split(parts[2], ",") {
+			addr = strings.Trim(strings.TrimSpace(addr), "[]")
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, fmt.Errorf("endereço IP inválido %q na resolução %q", addr, entry)
+			}
+			addrs = append(addrs, ip.String())
+		}
+
+		key := strings.ToLower(net.JoinHostPort(parts[0], parts[1]))
+		overrides[key] = append(overrides[key], addrs...)
+	}
+
+	return overrides, nil
+}
+
+// dnsServerAddress completa o endereço do servidor DNS com a porta 53
+func dnsServerAddress(server string) (string, error) {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server, nil
+	}
+	host := strings.Trim(server, "[]")
+	if host == "" {
+		return "", fmt.Errorf("servidor DNS inválido %q", server)
+	}
+	return net.JoinHostPort(host, "53"), nil
+}
+
+// customDNS indica se as opções alteram a resolução de nomes padrão
+func customDNS(options models.DNSOptions) bool {
+	return len(options.Resolve) > 0 || options.Server != "" || options.RoundRobin
+}
+
+// DescribeDNS resume a configuração de DNS para exibição; vazio quando é a padrão
+func DescribeDNS(options models.DNSOptions) string {
+	var parts []string
+	if len(options.Resolve) > 0 {
+		parts = append(parts, "fixo "+strings.Join(options.Resolve, ", "))
+	}
+	if options.Server != "" {
+		parts = append(parts, "servidor "+options.Server)
+	}
+	if options.RoundRobin {
+		parts = append(parts, "rodízio entre endereços")
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,86 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"syscall"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestParseResolve(t *testing.T) {
+	overrides, err := parseResolve([]string{"API.example.com:443:10.0.0.1,10.0.0.2", "example.com:80:[::1]"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := overrides["api.example.com:443"]; !reflect.DeepEqual(got, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Errorf("Expected both addresses, got %v", got)
+	}
+	if got := overrides["example.com:80"]; !reflect.DeepEqual(got, []string{"::1"}) {
+		t.Errorf("Expected the IPv6 address, got %v", got)
+	}
+
+	for _, entry := range []string{"example.com:443", "example.com:http:10.0.0.1", "example.com:443:backend"} {
+		if _, err := parseResolve([]string{entry}); err == nil {
+			t.Errorf("Expected an error for %q", entry)
+		}
+	}
+}
+
+func TestDialerRoundRobin(t *testing.T) {
+	d, err := newDialer(models.DNSOptions{
+		Resolve:    []string{"api.example.com:443:10.0.0.1,10.0.0.2,10.0.0.3"},
+		RoundRobin: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Registra o primeiro endereço tentado em cada conexão, sem conectar
+	errRefused := errors.New("recusada")
+	var dialed []string
+	d.net.Control = func(_, address string, _ syscall.RawConn) error {
+		dialed = append(dialed, address)
+		return errRefused
+	}
+
+	var first []string
+	for i := 0; i < 4; i++ {
+		dialed = nil
+		if _, err := d.DialContext(context.Background(), "tcp", "api.example.com:443"); err == nil {
+			t.Fatal("Expected the dial to fail")
+		}
+		if len(dialed) != 3 {
+			t.Fatalf("Expected every address to be tried, got %v", dialed)
+		}
+		first = append(first, dialed[0])
+	}
+
+	expected := []string{"10.0.0.1:443", "10.0.0.2:443", "10.0.0.3:443", "10.0.0.1:443"}
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("Expected %v, got %v", expected, first)
+	}
+}
+
+func TestDialerWithoutOverride(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	d, err := newDialer(models.DNSOptions{Resolve: []string{"outro.example.com:443:10.0.0.1"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	conn, err := d.DialContext(context.Background(), "tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Expected a direct connection, got %v", err)
+	}
+	conn.Close()
+}
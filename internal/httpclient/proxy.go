@@ -0,0 +1,162 @@
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// parseProxy valida a URL do proxy: http, https ou socks5, com credenciais opcionais
+func parseProxy(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("URL do proxy inválida: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("esquema de proxy não suportado %q: use http, https ou socks5", proxyURL.Scheme)
+	}
+
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("URL do proxy sem host: %q", raw)
+	}
+
+	return proxyURL, nil
+}
+
+// DescribeProxy retorna a URL do proxy sem a senha, para exibição
+func DescribeProxy(raw string) string {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return proxyURL.Redacted()
+}
+
+// proxyFunc retorna a seleção de proxy do transporte: todas as requisições
+// usam o proxy, exceto as destinadas aos hosts listados em NO_PROXY
+func proxyFunc(proxyURL *url.URL) func(*http.Request) (*url.URL, error) {
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	bypass := parseNoProxy(noProxy)
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypass.matches(req.URL) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}
+}
+
+// noProxyRule é uma entrada de NO_PROXY: um domínio, um IP ou uma faixa CIDR,
+// opcionalmente restrita a uma porta (exceto a faixa CIDR)
+type noProxyRule struct {
+	// domain é o sufixo, sempre iniciado por ".", que identifica os subdomínios
+	domain string
+	// matchHost indica se o próprio domínio também é dispensado do proxy
+	matchHost bool
+	network   *net.IPNet
+	ip        net.IP
+	port      string
+}
+
+// noProxyRules é a lista de destinos acessados sem proxy
+type noProxyRules struct {
+	all   bool
+	rules []noProxyRule
+}
+
+// parseNoProxy interpreta a lista separada por vírgulas do NO_PROXY com a mesma
+// semântica do Go (golang.org/x/net/http/httpproxy): "*" dispensa o proxy para
+// todos os destinos, "example.com" vale para o domínio e os seus subdomínios e
+// ".example.com" ou "*.example.com" apenas para os subdomínios; as faixas CIDR
+// e os domínios não se aplicam, respectivamente, a nomes e a IPs. Ao contrário do
+// Go, destinos locais só deixam de usar o proxy quando listados
+func parseNoProxy(value string) noProxyRules {
+	var rules noProxyRules
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			rules.all = true
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			rules.rules = append(rules.rules, noProxyRule{network: network})
+			continue
+		}
+
+		var rule noProxyRule
+		host := entry
+		if h, port, err := net.SplitHostPort(entry); err == nil {
+			host, rule.port = strings.TrimSuffix(strings.TrimPrefix(h, "["), "]"), port
+		}
+		// Entradas sem host, como ":8080", são ignoradas
+		if host == "" {
+			continue
+		}
+
+		if ip := net.ParseIP(host); ip != nil {
+			rule.ip = ip
+		} else {
+			rule.domain = host
+			if strings.HasPrefix(host, "*.") {
+				rule.domain = host[1:]
+			}
+			if !strings.HasPrefix(rule.domain, ".") {
+				rule.domain = "." + rule.domain
+				rule.matchHost = true
+			}
+		}
+		rules.rules = append(rules.rules, rule)
+	}
+
+	return rules
+}
+
+// matches indica se o destino deve ser acessado sem proxy
+func (r noProxyRules) matches(target *url.URL) bool {
+	if r.all {
+		return true
+	}
+
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443", "socks5": "1080"}[target.Scheme]
+	}
+	ip := net.ParseIP(host)
+
+	for _, rule := range r.rules {
+		if rule.port != "" && rule.port != port {
+			continue
+		}
+		switch {
+		case rule.network != nil:
+			if ip != nil && rule.network.Contains(ip) {
+				return true
+			}
+		case rule.ip != nil:
+			if ip != nil && rule.ip.Equal(ip) {
+				return true
+			}
+		case ip != nil:
+			// Domínios não se aplicam a destinos informados por IP
+		case strings.HasSuffix(host, rule.domain) || (rule.matchHost && host == rule.domain[1:]):
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"stresstest/internal/models"
+)
+
+// tlsVersions associa os nomes aceitos nas opções às versões do protocolo
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig monta a configuração TLS: certificado do cliente (mTLS),
+// autoridades certificadoras, verificação, SNI, versões e cifras
+func newTLSConfig(options models.TLSOptions) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: options.Insecure,
+		ServerName:         options.ServerName,
+	}
+
+	if (options.CertFile == "") != (options.KeyFile == "") {
+		return nil, fmt.Errorf("o certificado e a chave do cliente devem ser informados juntos")
+	}
+	if options.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar o certificado do cliente: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if options.CAFile != "" {
+		pem, err := os.ReadFile(options.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler as autoridades certificadoras: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("nenhum certificado PEM encontrado em %s", options.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	var err error
+	if config.MinVersion, err = parseVersion(options.MinVersion); err != nil {
+		return nil, err
+	}
+	if config.MaxVersion, err = parseVersion(options.MaxVersion); err != nil {
+		return nil, err
+	}
+	if config.MinVersion != 0 && config.MaxVersion != 0 && config.MinVersion > config.MaxVersion {
+		return nil, fmt.Errorf("a versão TLS mínima é maior que a máxima")
+	}
+
+	if config.CipherSuites, err = parseCipherSuites(options.CipherSuites); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// parseVersion interpreta uma versão TLS como "1.2"; vazio usa o padrão do Go
+func parseVersion(name string) (uint16, error) {
+	if name == "" {
+		return 0, nil
+	}
+	version, ok := tlsVersions[strings.TrimPrefix(strings.ToLower(name), "tls")]
+	if !ok {
+		return 0, fmt.Errorf("versão TLS inválida %q: use 1.0, 1.1, 1.2 ou 1.3", name)
+	}
+	return version, nil
+}
+
+// parseCipherSuites converte os nomes das cifras (ex: TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)
+// nos seus identificadores. As cifras do TLS 1.3 não são configuráveis
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	available := make(map[string]uint16)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		available[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := available[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("cifra TLS desconhecida %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Describe resume a configuração TLS para o cabeçalho do teste; vazio quando
+// a configuração padrão é usada
+func Describe(options models.TLSOptions) string {
+	var parts []string
+	if options.CertFile != "" {
+		parts = append(parts, "certificado do cliente (mTLS)")
+	}
+	if options.CAFile != "" {
+		parts = append(parts, "CA "+options.CAFile)
+	}
+	if options.Insecure {
+		parts = append(parts, "sem verificação do certificado")
+	}
+	if options.ServerName != "" {
+		parts = append(parts, "SNI "+options.ServerName)
+	}
+	if options.MinVersion != "" || options.MaxVersion != "" {
+		parts = append(parts, fmt.Sprintf("versões %s-%s", orDefault(options.MinVersion), orDefault(options.MaxVersion)))
+	}
+	if len(options.CipherSuites) > 0 {
+		parts = append(parts, fmt.Sprintf("%d cifras", len(options.CipherSuites)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// orDefault exibe versões não informadas como "padrão"
+func orDefault(version string) string {
+	if version == "" {
+		return "padrão"
+	}
+	return version
+}
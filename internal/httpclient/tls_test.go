@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestTransportWithCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certificate, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(options models.TLSOptions) error {
+		transport, err := NewTransport(models.ClientOptions{TLS: options})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer transport.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(models.TLSOptions{}); err == nil {
+		t.Error("Expected an error without the server CA")
+	}
+	if err := get(models.TLSOptions{CAFile: caFile, ServerName: "example.com", MaxVersion: "1.2"}); err != nil {
+		t.Errorf("Expected the custom CA to be trusted, got %v", err)
+	}
+	if err := get(models.TLSOptions{Insecure: true}); err != nil {
+		t.Errorf("Expected no error with verification disabled, got %v", err)
+	}
+}
+
+func TestTLSConfigOptions(t *testing.T) {
+	config, err := newTLSConfig(models.TLSOptions{
+		MinVersion:   "1.2",
+		MaxVersion:   "tls1.3",
+		CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.MinVersion != tls.VersionTLS12 || config.MaxVersion != tls.VersionTLS13 {
+		t.Errorf("Expected TLS 1.2-1.3, got %x-%x", config.MinVersion, config.MaxVersion)
+	}
+	if len(config.CipherSuites) != 1 || config.CipherSuites[0] != tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 {
+		t.Errorf("Expected a single cipher suite, got %v", config.CipherSuites)
+	}
+
+	invalid := []models.TLSOptions{
+		{MinVersion: "2.0"},
+		{MinVersion: "1.3", MaxVersion: "1.2"},
+		{CipherSuites: []string{"TLS_NOPE"}},
+		{CertFile: "cert.pem"},
+	}
+	for _, options := range invalid {
+		if _, err := newTLSConfig(options); err == nil {
+			t.Errorf("Expected an error for %+v", options)
+		}
+	}
+}
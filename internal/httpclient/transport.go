@@ -0,0 +1,77 @@
+// Package httpclient monta o transporte HTTP usado pelos workers a partir das
+// opções do cliente, como a configuração TLS, o proxy, a resolução de nomes e
+// o HTTP/2 sem TLS, com suporte a sockets UNIX
+package httpclient
+
+import (
+	"net/http"
+
+	"stresstest/internal/models"
+)
+
+// Custom indica se as opções exigem um transporte próprio em vez do padrão
+func Custom(options models.ClientOptions) bool {
+	tls := options.TLS
+	return options.Proxy != "" || customDNS(options.DNS) || options.H2C || tls.CertFile != "" || tls.KeyFile != "" || tls.CAFile != "" || tls.Insecure ||
+		tls.ServerName != "" || tls.MinVersion != "" || tls.MaxVersion != "" || len(tls.CipherSuites) > 0
+}
+
+// Default retorna o transporte usado sem opções do cliente: o padrão do
+// pacote net/http, com suporte a sockets UNIX
+func Default() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	registerUnix(transport)
+	return transport
+}
+
+// Clone cria um transporte com a mesma configuração do informado, mas sem
+// conexões em comum, inclusive as dos sockets UNIX
+func Clone(transport *http.Transport) *http.Transport {
+	clone := transport.Clone()
+	registerUnix(clone)
+	return clone
+}
+
+// NewTransport cria um transporte com a configuração padrão do pacote net/http
+// e as opções informadas
+func NewTransport(options models.ClientOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig, err := newTLSConfig(options.TLS)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// Sem proxy informado, vale o padrão do pacote net/http (HTTP_PROXY,
+	// HTTPS_PROXY e NO_PROXY)
+	if options.Proxy != "" {
+		proxyURL, err := parseProxy(options.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxyFunc(proxyURL)
+	}
+
+	// O Host e o SNI continuam os da URL: só o endereço conectado muda
+	if customDNS(options.DNS) {
+		dialer, err := newDialer(options.DNS)
+		if err != nil {
+			return nil, err
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	// Sem HTTP/1 habilitado, as URLs http:// usam HTTP/2 sem TLS desde o
+	// início da conexão (prior knowledge); as https:// exigem HTTP/2 via ALPN
+	if options.H2C {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = protocols
+	}
+
+	registerUnix(transport)
+
+	return transport, nil
+}
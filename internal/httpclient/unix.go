@@ -0,0 +1,86 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SplitUnixURL separa uma URL unix:///caminho/do.sock:/caminho/http no
+// caminho do socket e no caminho HTTP (por padrão "/")
+func SplitUnixURL(target *url.URL) (socket, path string, err error) {
+	if target.Scheme != "unix" {
+		return "", "", fmt.Errorf("URL %q não usa o esquema unix://", target)
+	}
+	if target.Host != "" || !strings.HasPrefix(target.Path, "/") {
+		return "", "", fmt.Errorf("URL %q deve informar o caminho absoluto do socket: unix:///caminho/do.sock:/caminho", target)
+	}
+
+	socket, path, _ = strings.Cut(target.Path, ":")
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "", "", fmt.Errorf("o caminho HTTP de %q deve começar com /", target)
+	}
+
+	return socket, path, nil
+}
+
+// unixTransport atende as requisições unix://, convertendo-as em requisições
+// HTTP enviadas pelo socket
+type unixTransport struct {
+	inner *http.Transport
+}
+
+// registerUnix habilita URLs unix:// no transporte. As conexões usam uma cópia
+// da configuração (HTTP/2, tempos limite), sem proxy nem DNS
+func registerUnix(transport *http.Transport) {
+	inner := transport.Clone()
+	inner.Proxy = nil
+	inner.DialTLSContext = nil
+	inner.DialContext = dialUnix
+	transport.RegisterProtocol("unix", unixTransport{inner: inner})
+}
+
+// RoundTrip envia a requisição pelo socket. O host da URL interna identifica
+// o socket, separando as conexões de sockets diferentes; o cabeçalho Host é
+// "localhost" quando não informado
+func (t unixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	socket, path, err := SplitUnixURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	unixReq := req.Clone(req.Context())
+	unixReq.URL = &url.URL{
+		Scheme:   "http",
+		Host:     hex.EncodeToString([]byte(socket)),
+		Path:     path,
+		RawQuery: req.URL.RawQuery,
+	}
+	if unixReq.Host == "" {
+		unixReq.Host = "localhost"
+	}
+
+	return t.inner.RoundTrip(unixReq)
+}
+
+// dialUnix conecta ao socket identificado pelo host do endereço
+func dialUnix(ctx context.Context, _, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	socket, err := hex.DecodeString(host)
+	if err != nil {
+		return nil, fmt.Errorf("endereço de socket inválido %q", host)
+	}
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", string(socket))
+}
@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestSplitUnixURL(t *testing.T) {
+	tests := []struct {
+		raw    string
+		socket string
+		path   string
+	}{
+		{"unix:///var/run/app.sock:/api/health", "/var/run/app.sock", "/api/health"},
+		{"unix:///var/run/app.sock", "/var/run/app.sock", "/"},
+	}
+
+	for _, test := range tests {
+		target, _ := url.Parse(test.raw)
+		socket, path, err := SplitUnixURL(target)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", test.raw, err)
+		}
+		if socket != test.socket || path != test.path {
+			t.Errorf("Expected %s and %s, got %s and %s", test.socket, test.path, socket, path)
+		}
+	}
+
+	for _, raw := range []string{"unix://app.sock:/api", "unix:///app.sock:api"} {
+		target, _ := url.Parse(raw)
+		if _, _, err := SplitUnixURL(target); err == nil {
+			t.Errorf("Expected an error for %s", raw)
+		}
+	}
+}
+
+func TestUnixSocketWithH2C(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "app.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("UNIX sockets unavailable: %v", err)
+	}
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{
+		Protocols: protocols,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.Proto+" "+r.Host+" "+r.URL.String())
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	get := func(transport *http.Transport) string {
+		defer transport.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: transport}).Get("unix://" + socket + ":/api?x=1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if got := get(Default()); got != "HTTP/1.1 localhost /api?x=1" {
+		t.Errorf("Expected an HTTP/1.1 request over the socket, got %q", got)
+	}
+
+	transport, err := NewTransport(models.ClientOptions{H2C: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := get(Clone(transport)); got != "HTTP/2.0 localhost /api?x=1" {
+		t.Errorf("Expected an h2c request over the socket, got %q", got)
+	}
+}
@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"math"
+	"math/bits"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+const (
+	// subBucketBits define a precisão: cada potência de 2 é dividida em
+	// 2^(subBucketBits-1) faixas lineares, com erro relativo máximo de ~1,6%
+	subBucketBits  = 7
+	subBucketCount = 1 << subBucketBits
+	subBucketHalf  = subBucketCount / 2
+
+	// maxValueBits limita os valores registrados a 2^36 µs (~19 horas)
+	maxValueBits = 36
+	maxValue     = 1<<maxValueBits - 1
+
+	bucketCount = (maxValueBits - subBucketBits + 2) * subBucketHalf
+)
+
+// Histogram é um histograma log-linear de memória fixa, no estilo HDR, para
+// distribuições de latência. Os valores são registrados em microssegundos;
+// mínimo, máximo, média e desvio padrão são exatos e os percentis têm erro
+// relativo limitado pela precisão das faixas. Não é seguro para uso concorrente
+type Histogram struct {
+	counts     []uint64
+	count      uint64
+	min        time.Duration
+	max        time.Duration
+	sum        float64
+	sumSquares float64
+}
+
+// NewHistogram cria um histograma vazio
+func NewHistogram() *Histogram {
+	return &Histogram{
+		counts: make([]uint64, bucketCount),
+	}
+}
+
+// Record registra uma duração no histograma
+func (h *Histogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	h.counts[bucketIndex(uint64(d/time.Microsecond))]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+
+	value := float64(d)
+	h.sum += value
+	h.sumSquares += value * value
+}
+
+// Merge acumula as contagens de outro histograma neste
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+	if h.count == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+	h.count += other.count
+	h.sum += other.sum
+	h.sumSquares += other.sumSquares
+}
+
+// Count retorna o número de valores registrados
+func (h *Histogram) Count() int {
+	return int(h.count)
+}
+
+// Min retorna o menor valor registrado
+func (h *Histogram) Min() time.Duration {
+	return h.min
+}
+
+// Max retorna o maior valor registrado
+func (h *Histogram) Max() time.Duration {
+	return h.max
+}
+
+// Mean retorna a média dos valores registrados
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / float64(h.count))
+}
+
+// StdDev retorna o desvio padrão populacional dos valores registrados
+func (h *Histogram) StdDev() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+
+	mean := h.sum / float64(h.count)
+	variance := h.sumSquares/float64(h.count) - mean*mean
+	if variance <= 0 {
+		return 0
+	}
+	return time.Duration(math.Sqrt(variance))
+}
+
+// Quantile retorna o valor abaixo do qual está a fração q (0 a 1) dos valores
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return h.min
+	}
+	if q >= 1 {
+		return h.max
+	}
+
+	rank := uint64(math.Ceil(q * float64(h.count)))
+	var cumulative uint64
+	for i, count := range h.counts {
+		cumulative += count
+		if cumulative >= rank {
+			return h.clamp(bucketMidpoint(i))
+		}
+	}
+
+	return h.max
+}
+
+// Percentiles extrai os percentis exibidos nos relatórios
+func (h *Histogram) Percentiles() models.Percentiles {
+	return models.Percentiles{
+		P50:  h.Quantile(0.50),
+		P75:  h.Quantile(0.75),
+		P90:  h.Quantile(0.90),
+		P95:  h.Quantile(0.95),
+		P99:  h.Quantile(0.99),
+		P999: h.Quantile(0.999),
+	}
+}
+
+// Bucket é uma faixa da distribuição dos valores registrados
+type Bucket struct {
+	Lower time.Duration
+	Upper time.Duration
+	Count int
+}
+
+// Distribution agrupa os valores registrados em até n faixas de largura
+// logarítmica entre o mínimo e o máximo, para exibição da distribuição. Cada
+// valor é atribuído pela faixa interna em que foi registrado, então a
+// contagem de cada faixa tem a mesma precisão dos percentis
+func (h *Histogram) Distribution(n int) []Bucket {
+	if h.count == 0 || n < 1 {
+		return nil
+	}
+
+	// O limite inferior de 1µs evita o logaritmo de zero
+	lower := math.Max(float64(h.min/time.Microsecond), 1)
+	upper := math.Max(float64(h.max/time.Microsecond), lower)
+	if upper == lower {
+		return []Bucket{{Lower: h.min, Upper: h.max, Count: int(h.count)}}
+	}
+
+	ratio := math.Log(upper / lower)
+	buckets := make([]Bucket, n)
+	for i := range buckets {
+		buckets[i].Lower = time.Duration(lower*math.Exp(ratio*float64(i)/float64(n))) * time.Microsecond
+		buckets[i].Upper = time.Duration(lower*math.Exp(ratio*float64(i+1)/float64(n))) * time.Microsecond
+	}
+	buckets[0].Lower = h.min
+	buckets[n-1].Upper = h.max
+
+	for i, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+		value := math.Max(float64(h.clamp(bucketMidpoint(i))/time.Microsecond), lower)
+		index := int(float64(n) * math.Log(value/lower) / ratio)
+		if index >= n {
+			index = n - 1
+		}
+		buckets[index].Count += int(count)
+	}
+
+	return buckets
+}
+
+// clamp limita um valor aproximado ao intervalo exato [min, max]
+func (h *Histogram) clamp(d time.Duration) time.Duration {
+	if d < h.min {
+		return h.min
+	}
+	if d > h.max {
+		return h.max
+	}
+	return d
+}
+
+// Millis converte uma duração em milissegundos com precisão de microssegundos,
+// a mesma do histograma, para exportação em relatórios e arquivos
+func Millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+// bucketIndex retorna a faixa de um valor em microssegundos. Valores menores
+// que subBucketCount têm faixas exatas; acima disso cada potência de 2 ocupa
+// subBucketHalf faixas de mesma largura
+func bucketIndex(value uint64) int {
+	if value > maxValue {
+		value = maxValue
+	}
+	if value < subBucketCount {
+		return int(value)
+	}
+
+	exponent := bits.Len64(value) - subBucketBits
+	return exponent*subBucketHalf + int(value>>exponent)
+}
+
+// bucketBounds retorna o menor e o maior valor (em microssegundos) de uma faixa
+func bucketBounds(index int) (uint64, uint64) {
+	if index < subBucketCount {
+		return uint64(index), uint64(index)
+	}
+
+	exponent := index/subBucketHalf - 1
+	sub := uint64(index - exponent*subBucketHalf)
+	return sub << exponent, (sub+1)<<exponent - 1
+}
+
+// bucketMidpoint retorna o valor representativo de uma faixa
+func bucketMidpoint(index int) time.Duration {
+	lower, upper := bucketBounds(index)
+	return time.Duration(lower+(upper-lower)/2) * time.Microsecond
+}
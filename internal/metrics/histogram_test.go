@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestHistogramQuantiles(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 10000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	if h.Count() != 10000 {
+		t.Errorf("Expected Count to be 10000, got %d", h.Count())
+	}
+
+	if h.Min() != time.Millisecond {
+		t.Errorf("Expected Min to be 1ms, got %v", h.Min())
+	}
+
+	if h.Max() != 10*time.Second {
+		t.Errorf("Expected Max to be 10s, got %v", h.Max())
+	}
+
+	if h.Mean() != 5000500*time.Microsecond {
+		t.Errorf("Expected Mean to be 5.0005s, got %v", h.Mean())
+	}
+
+	tests := []struct {
+		quantile float64
+		expected time.Duration
+	}{
+		{0.50, 5000 * time.Millisecond},
+		{0.90, 9000 * time.Millisecond},
+		{0.99, 9900 * time.Millisecond},
+		{0.999, 9990 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		got := h.Quantile(test.quantile)
+		relativeError := math.Abs(float64(got-test.expected)) / float64(test.expected)
+		if relativeError > 0.016 {
+			t.Errorf("Expected p%v to be about %v, got %v (error %.2f%%)",
+				test.quantile*100, test.expected, got, relativeError*100)
+		}
+	}
+}
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	p := h.Percentiles()
+	got := []time.Duration{p.P50, p.P75, p.P90, p.P95, p.P99, p.P999}
+	for i, quantile := range []float64{0.50, 0.75, 0.90, 0.95, 0.99, 0.999} {
+		if got[i] != h.Quantile(quantile) {
+			t.Errorf("Expected p%v to be %v, got %v", quantile*100, h.Quantile(quantile), got[i])
+		}
+	}
+
+	if (NewHistogram().Percentiles() != models.Percentiles{}) {
+		t.Error("Expected an empty histogram to have zero percentiles")
+	}
+}
+
+func TestHistogramStdDev(t *testing.T) {
+	h := NewHistogram()
+	for _, d := range []time.Duration{2, 4, 4, 4, 5, 5, 7, 9} {
+		h.Record(d * time.Millisecond)
+	}
+
+	if h.StdDev() != 2*time.Millisecond {
+		t.Errorf("Expected StdDev to be 2ms, got %v", h.StdDev())
+	}
+}
+
+func TestHistogramSmallValuesAreExact(t *testing.T) {
+	h := NewHistogram()
+	for i := 0; i < 100; i++ {
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+
+	if got := h.Quantile(0.5); got != 49*time.Microsecond {
+		t.Errorf("Expected p50 to be 49µs, got %v", got)
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a := NewHistogram()
+	b := NewHistogram()
+	a.Record(10 * time.Millisecond)
+	b.Record(30 * time.Millisecond)
+	b.Record(20 * time.Millisecond)
+
+	a.Merge(b)
+
+	if a.Count() != 3 {
+		t.Errorf("Expected Count to be 3, got %d", a.Count())
+	}
+
+	if a.Min() != 10*time.Millisecond || a.Max() != 30*time.Millisecond {
+		t.Errorf("Expected range 10ms-30ms, got %v-%v", a.Min(), a.Max())
+	}
+
+	if a.Mean() != 20*time.Millisecond {
+		t.Errorf("Expected Mean to be 20ms, got %v", a.Mean())
+	}
+}
+
+func TestBucketIndexRoundTrip(t *testing.T) {
+	for _, value := range []uint64{0, 1, 127, 128, 255, 256, 1000, 123456, 1 << 30, maxValue} {
+		lower, upper := bucketBounds(bucketIndex(value))
+		if value < lower || value > upper {
+			t.Errorf("Expected %d to be within bucket [%d, %d]", value, lower, upper)
+		}
+	}
+
+	if bucketIndex(maxValue) != bucketCount-1 {
+		t.Errorf("Expected max value in last bucket %d, got %d", bucketCount-1, bucketIndex(maxValue))
+	}
+}
+
+func TestHistogramDistribution(t *testing.T) {
+	h := NewHistogram()
+	if buckets := h.Distribution(10); buckets != nil {
+		t.Errorf("Expected no buckets for an empty histogram, got %v", buckets)
+	}
+
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	buckets := h.Distribution(10)
+	if len(buckets) != 10 {
+		t.Fatalf("Expected 10 buckets, got %d", len(buckets))
+	}
+	if buckets[0].Lower != time.Millisecond || buckets[9].Upper != time.Second {
+		t.Errorf("Expected buckets from 1ms to 1s, got %v to %v", buckets[0].Lower, buckets[9].Upper)
+	}
+
+	total := 0
+	for i, bucket := range buckets {
+		total += bucket.Count
+		if i > 0 && bucket.Lower != buckets[i-1].Upper {
+			t.Errorf("Expected bucket %d to start at %v, got %v", i, buckets[i-1].Upper, bucket.Lower)
+		}
+	}
+	if total != 1000 {
+		t.Errorf("Expected 1000 values in the buckets, got %d", total)
+	}
+
+	// Com escala logarítmica a última faixa (~500ms a 1s) tem cerca de metade dos valores
+	if buckets[9].Count < 450 || buckets[9].Count > 550 {
+		t.Errorf("Expected about 500 values in the last bucket, got %d", buckets[9].Count)
+	}
+}
@@ -1,41 +1,474 @@
 package models
 
 import (
+	"net/http"
 	"time"
 )
 
 // TestConfig contém a configuração para o teste de carga
 type TestConfig struct {
-	URL         string
+	URL string
+	// Method é o método HTTP das requisições; quando vazio, é usado GET
+	Method string
+	// Headers são adicionados a todas as requisições
+	Headers http.Header
+	// Body é o corpo enviado em todas as requisições
+	Body []byte
+	// Targets substitui URL, Method e Body por um conjunto de requisições
+	// selecionadas a cada iteração; Headers é aplicado como padrão a todas
+	Targets []RequestSpec
+	// TargetOrder é OrderSequential ou OrderRandom; quando vazio, sequencial
+	TargetOrder string
+	// Flow descreve a jornada de cada usuário virtual; quando informado, cada
+	// iteração executa todos os passos em ordem, no lugar de Targets
+	Flow []FlowStep
+	// Feeders fornecem dados às requisições: a cada iteração, as colunas do
+	// próximo registro de cada fonte viram variáveis dos templates
+	Feeders []Feeder
+
 	Requests    int
 	Concurrency int
+	// Duration limita o teste por tempo; quando zero, apenas Requests é usado
+	Duration time.Duration
+	// Rate ativa o modo aberto: requisições por segundo agendadas em uma linha
+	// do tempo fixa, independente do tempo de resposta. Concurrency passa a ser
+	// o limite de requisições em andamento
+	Rate float64
+	// Stages descreve um perfil de carga em estágios; quando informado, o
+	// número de usuários virtuais varia durante o teste em vez de Concurrency
+	Stages []Stage
+	// KeepResults mantém cada RequestResult em StressTestResult.Results; por
+	// padrão apenas as estatísticas agregadas são guardadas. Destina-se a quem
+	// usa o pacote diretamente: a linha de comando não mantém as requisições em
+	// memória e oferece --results-file para gravá-las em arquivo
+	KeepResults bool
+	// ResultsFile recebe cada RequestResult à medida que o teste avança, no
+	// formato ResultsFormat (ResultsCSV ou ResultsJSONL; quando vazio, deduzido
+	// pela extensão do arquivo). Vazio desativa a exportação
+	ResultsFile   string
+	ResultsFormat string
+	// MetricsInterval é a largura de cada intervalo da série temporal; quando
+	// zero, é usado um segundo
+	MetricsInterval time.Duration
+	// Client descreve como os workers se conectam ao serviço
+	Client ClientOptions
+	// Auth descreve as credenciais aplicadas a todas as requisições
+	Auth AuthOptions
+	// Checks são as verificações aplicadas às requisições sem verificações próprias
+	Checks *Checks
+	// Thresholds são os limites (SLOs) avaliados ao fim do teste, como p95<250ms
+	Thresholds []string
+}
+
+// AuthOptions descreve a autenticação das requisições. Um cabeçalho
+// Authorization definido na própria requisição tem precedência
+type AuthOptions struct {
+	// Type é AuthBearer, AuthBasic ou AuthOAuth2; vazio sem autenticação
+	Type string
+	// Token é o token da autenticação bearer
+	Token string
+	// Username e Password são as credenciais da autenticação basic
+	Username string
+	Password string
+	// TokenURL, ClientID, ClientSecret e Scopes configuram o fluxo OAuth2
+	// client credentials
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// Formatos de TestConfig.ResultsFormat
+const (
+	ResultsCSV   = "csv"
+	ResultsJSONL = "jsonl"
+)
+
+// Tipos de autenticação de AuthOptions
+const (
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+	AuthOAuth2 = "oauth2"
+)
+
+// ClientOptions contém as opções do cliente HTTP usado pelos workers
+type ClientOptions struct {
+	// CookieJar dá a cada worker (usuário virtual) o seu próprio armazenamento
+	// de cookies, mantido entre as iterações
+	CookieJar bool
+	// IsolatedConnections dá a cada worker o seu próprio transporte, sem
+	// compartilhar conexões nem sessões TLS com os demais
+	IsolatedConnections bool
+	// TLS contém a configuração TLS das conexões
+	TLS TLSOptions
+	// Proxy é a URL do proxy (http://, https:// ou socks5://, com credenciais
+	// opcionais) usado em todas as requisições, exceto nos destinos de NO_PROXY
+	Proxy string
+	// DNS controla a resolução de nomes das conexões
+	DNS DNSOptions
+	// H2C usa HTTP/2 sem TLS (prior knowledge) nas URLs http://
+	H2C bool
+}
+
+// DNSOptions contém a configuração da resolução de nomes do cliente
+type DNSOptions struct {
+	// Resolve fixa os endereços de destinos, no formato "host:porta:endereço"
+	// (vários endereços separados por vírgula), como o --resolve do curl
+	Resolve []string
+	// Server é o servidor DNS ("host[:porta]") usado no lugar do do sistema
+	Server string
+	// RoundRobin distribui as novas conexões entre todos os endereços
+	// resolvidos (A e AAAA), em vez de usar o primeiro que responder
+	RoundRobin bool
+}
+
+// TLSOptions contém a configuração TLS do cliente
+type TLSOptions struct {
+	// CertFile e KeyFile são o certificado e a chave PEM do cliente (mTLS)
+	CertFile string
+	KeyFile  string
+	// CAFile contém as autoridades certificadoras (PEM) aceitas no lugar das do sistema
+	CAFile string
+	// Insecure desativa a verificação do certificado do servidor
+	Insecure bool
+	// ServerName substitui o nome enviado no SNI e usado na verificação
+	ServerName string
+	// MinVersion e MaxVersion limitam as versões do protocolo ("1.0" a "1.3")
+	MinVersion string
+	MaxVersion string
+	// CipherSuites restringe as cifras do TLS 1.2 e anteriores, pelo nome
+	CipherSuites []string
+}
+
+// RequestSpec descreve uma requisição HTTP enviada durante o teste
+type RequestSpec struct {
+	// Name identifica a requisição no relatório; quando vazio, usa-se "MÉTODO URL"
+	Name    string
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+	// Weight é o peso relativo da requisição na seleção; zero equivale a 1
+	Weight int
+	// Checks são as verificações da resposta; nil usa as de TestConfig.Checks
+	Checks *Checks
+}
+
+// Checks descreve as verificações aplicadas à resposta de uma requisição, que
+// só é bem-sucedida quando passa em todas
+type Checks struct {
+	// Status são os códigos aceitos; vazio mantém a regra padrão (2xx)
+	Status []int
+	// BodyContains são trechos que o corpo deve conter
+	BodyContains []string
+	// BodyRegex são expressões regulares que o corpo deve satisfazer
+	BodyRegex []string
+	// JSON são verificações de campos do corpo JSON
+	JSON []JSONCheck
+	// Headers são os cabeçalhos que devem estar presentes na resposta
+	Headers []string
+	// MaxLatency é a duração máxima da requisição; zero desativa
+	MaxLatency time.Duration
+	// MinSize e MaxSize limitam o tamanho do corpo em bytes; zero desativa
+	MinSize int64
+	MaxSize int64
+}
+
+// JSONCheck verifica um campo do corpo JSON, indicado por um caminho como
+// $.data.status
+type JSONCheck struct {
+	Path string
+	// Value é o valor esperado, comparado como texto; ignorado com Exists
+	Value string
+	// Exists exige apenas que o campo exista, com qualquer valor não nulo
+	Exists bool
+}
+
+// Ordens de seleção das requisições de TestConfig.Targets
+const (
+	// OrderSequential percorre as requisições em ciclo, respeitando os pesos
+	OrderSequential = "sequential"
+	// OrderRandom sorteia as requisições de acordo com os pesos
+	OrderRandom = "random"
+)
+
+// Feeder é uma fonte de dados tabulares (CSV ou JSON) usada nas requisições
+type Feeder struct {
+	// Name identifica a fonte nas mensagens, normalmente o nome do arquivo
+	Name    string
+	Records []map[string]string
+	// Strategy é FeedSequential, FeedRandom ou FeedUnique
+	Strategy string
+	// OnExhausted é ExhaustedRecycle ou ExhaustedStop
+	OnExhausted string
+}
+
+// Estratégias de leitura dos registros de um Feeder
+const (
+	// FeedSequential entrega os registros em ordem, compartilhados entre os workers
+	FeedSequential = "sequential"
+	// FeedRandom sorteia um registro a cada iteração
+	FeedRandom = "random"
+	// FeedUnique associa a cada worker um registro exclusivo durante todo o teste
+	FeedUnique = "unique"
+)
+
+// Comportamentos de um Feeder quando os registros se esgotam
+const (
+	// ExhaustedRecycle volta ao primeiro registro
+	ExhaustedRecycle = "recycle"
+	// ExhaustedStop encerra o teste
+	ExhaustedStop = "stop"
+)
+
+// FlowStep descreve um passo da jornada de um usuário virtual. URL, cabeçalhos e
+// corpo podem referenciar variáveis do usuário com {{nome}}
+type FlowStep struct {
+	Request RequestSpec
+	// Extract lista os valores extraídos da resposta para variáveis do usuário
+	Extract []Extraction
+}
+
+// Extraction descreve um valor extraído da resposta de um passo
+type Extraction struct {
+	// Var é o nome da variável do usuário que recebe o valor
+	Var string
+	// Source é ExtractJSON, ExtractRegex ou ExtractHeader
+	Source string
+	// Expression é o caminho JSON ($.data.token), a expressão regular (com o
+	// primeiro grupo de captura como valor) ou o nome do cabeçalho
+	Expression string
+}
+
+// Origens dos valores extraídos das respostas
+const (
+	ExtractJSON   = "json"
+	ExtractRegex  = "regex"
+	ExtractHeader = "header"
+)
+
+// Stage representa um estágio do perfil de carga: o número de usuários
+// virtuais varia linearmente até Target ao longo de Duration. Um estágio com
+// duração zero altera o número de usuários imediatamente
+type Stage struct {
+	Duration time.Duration
+	Target   int
 }
 
 // RequestResult representa o resultado de uma requisição individual
 type RequestResult struct {
+	// Timestamp é o instante de início da requisição (no modo de taxa
+	// constante, o instante planejado de envio)
+	Timestamp time.Time
+	// Worker identifica o worker (usuário virtual) que enviou a requisição
+	Worker       int
 	StatusCode   int
 	Duration     time.Duration
 	Error        error
 	ResponseSize int64
+	// Stage é o índice do estágio do perfil de carga em que a requisição foi iniciada
+	Stage int
+	// Timings contém a duração de cada fase da requisição
+	Timings PhaseTimings
+	// Target é o índice da requisição em TestConfig.Targets ou do passo em TestConfig.Flow
+	Target int
+	// Journey é preenchido no último passo executado de uma jornada
+	Journey *JourneyResult
+	// FailedChecks contém a descrição das verificações em que a resposta foi
+	// reprovada; falhas de verificação não são erros de transporte
+	FailedChecks []string
+	// StatusChecked indica que o código de status foi validado pelas
+	// verificações, no lugar da regra padrão (2xx)
+	StatusChecked bool
+}
+
+// JourneyResult representa o resultado de uma jornada completa de um usuário virtual
+type JourneyResult struct {
+	// Duration vai do início do primeiro passo ao fim do último executado
+	Duration time.Duration
+	// Success indica que todos os passos foram concluídos com sucesso
+	Success bool
+}
+
+// PhaseTimings contém a duração de cada fase de uma requisição HTTP. Fases que
+// não ocorreram (DNS, conexão e TLS em conexões reaproveitadas) ficam zeradas
+type PhaseTimings struct {
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// Proxy é o tempo de estabelecimento do túnel ou do handshake SOCKS com o
+	// proxy, após a conexão TCP com ele
+	Proxy time.Duration
+	// TTFB é o tempo entre o envio completo da requisição e o primeiro byte da resposta
+	TTFB time.Duration
+	// Transfer é o tempo de leitura do corpo da resposta após o primeiro byte
+	Transfer time.Duration
 }
 
 // TestReport contém os resultados consolidados do teste
 type TestReport struct {
-	TotalTime         time.Duration
-	TotalRequests     int
-	SuccessfulReqs    int
-	FailedReqs        int
-	StatusCodes       map[int]int
-	AvgResponseTime   time.Duration
-	MinResponseTime   time.Duration
-	MaxResponseTime   time.Duration
+	TotalTime      time.Duration
+	TotalRequests  int
+	SuccessfulReqs int
+	FailedReqs     int
+	StatusCodes    map[int]int
+	// ErrorCategories conta os erros de rede agrupados por categoria
+	ErrorCategories map[string]int
+	// CheckFailures conta as requisições reprovadas em ao menos uma verificação
+	CheckFailures int
+	// FailedChecks conta as reprovações de cada verificação, pela descrição
+	FailedChecks    map[string]int
+	AvgResponseTime time.Duration
+	MinResponseTime time.Duration
+	MaxResponseTime time.Duration
+	// StdDevResponseTime é o desvio padrão dos tempos de resposta
+	StdDevResponseTime time.Duration
+	// Percentiles contém os percentis dos tempos de resposta
+	Percentiles Percentiles
+	// LatencyDistribution agrupa os tempos de resposta em faixas de largura
+	// logarítmica entre o mínimo e o máximo
+	LatencyDistribution []HistogramBucket
+	// PercentileCurve contém os tempos de resposta em uma sequência de
+	// percentis, do mínimo ao máximo
+	PercentileCurve   []PercentilePoint
 	RequestsPerSec    float64
 	TotalDataTransfer int64
+	// DroppedIterations conta as iterações descartadas no modo de taxa constante
+	// porque o limite de requisições em andamento foi atingido
+	DroppedIterations int
+	// LateIterations conta as iterações despachadas com atraso em relação ao
+	// instante planejado no modo de taxa constante
+	LateIterations int
+	// Stages contém as estatísticas de cada estágio do perfil de carga
+	Stages []GroupReport
+	// Endpoints contém as estatísticas de cada requisição de TestConfig.Targets
+	// ou de cada passo de TestConfig.Flow
+	Endpoints []GroupReport
+	// Journeys contém as estatísticas das jornadas completas; nil sem TestConfig.Flow
+	Journeys *GroupReport
+	// TokenRequests contém as estatísticas das requisições de obtenção de token
+	// OAuth2, feitas fora da carga do teste; nil sem OAuth2
+	TokenRequests *GroupReport
+	// FeederExhausted indica que o teste foi encerrado porque os dados de um
+	// Feeder configurado para parar se esgotaram
+	FeederExhausted bool
+	// Phases contém as estatísticas de cada fase das requisições
+	Phases PhaseBreakdown
+	// TimeSeries contém as métricas de cada intervalo do teste, em ordem
+	TimeSeries []IntervalMetrics
+}
+
+// IntervalMetrics contém as métricas de um intervalo da série temporal do
+// teste; as requisições são atribuídas ao intervalo em que terminaram
+type IntervalMetrics struct {
+	// Offset é o início do intervalo em relação ao início do teste
+	Offset         time.Duration
+	Requests       int
+	FailedReqs     int
+	RequestsPerSec float64
+	// ErrorRate é o percentual de requisições com falha no intervalo
+	ErrorRate float64
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+	Bytes     int64
+	// InFlight é o maior número de requisições em andamento observado no intervalo
+	InFlight int
+}
+
+// PhaseStats contém as estatísticas de uma fase das requisições; Count é o
+// número de requisições em que a fase ocorreu
+type PhaseStats struct {
+	Count       int
+	Avg         time.Duration
+	Percentiles Percentiles
+}
+
+// PhaseBreakdown contém as estatísticas de cada fase das requisições
+type PhaseBreakdown struct {
+	DNS      PhaseStats
+	Connect  PhaseStats
+	Proxy    PhaseStats
+	TLS      PhaseStats
+	TTFB     PhaseStats
+	Transfer PhaseStats
+}
+
+// Percentiles contém os percentis de uma distribuição de latências
+type Percentiles struct {
+	P50  time.Duration
+	P75  time.Duration
+	P90  time.Duration
+	P95  time.Duration
+	P99  time.Duration
+	P999 time.Duration
+}
+
+// HistogramBucket é uma faixa da distribuição dos tempos de resposta
+type HistogramBucket struct {
+	Lower time.Duration
+	Upper time.Duration
+	Count int
+}
+
+// PercentilePoint é o tempo de resposta em um percentil (0 a 100)
+type PercentilePoint struct {
+	Percentile float64
+	Latency    time.Duration
+}
+
+// GroupReport contém as estatísticas consolidadas de um subconjunto das requisições
+type GroupReport struct {
+	Name            string
+	TotalRequests   int
+	SuccessfulReqs  int
+	FailedReqs      int
+	AvgResponseTime time.Duration
+	MaxResponseTime time.Duration
+	Percentiles     Percentiles
+	RequestsPerSec  float64
 }
 
 // StressTestResult encapsula todos os dados do teste
 type StressTestResult struct {
-	Config  TestConfig
-	Report  TestReport
+	Config TestConfig
+	Report TestReport
+	// Results contém cada requisição apenas quando Config.KeepResults é verdadeiro
 	Results []RequestResult
+	// Thresholds contém o resultado de cada limite de Config.Thresholds
+	Thresholds []ThresholdResult
+	// ResultsFileError é a falha na gravação de Config.ResultsFile; o teste e o
+	// relatório não são afetados, mas o arquivo pode estar incompleto
+	ResultsFileError error
+}
+
+// ThresholdResult é o resultado da avaliação de um limite (SLO)
+type ThresholdResult struct {
+	// Expression é o limite avaliado, como p95<250ms
+	Expression string
+	// Actual é o valor medido, formatado na unidade da métrica
+	Actual string
+	Passed bool
+}
+
+// CapacityPoint representa uma medição da busca de capacidade em um nível de carga
+type CapacityPoint struct {
+	// Level é a concorrência ou a taxa (req/s) aplicada, conforme o modo da busca
+	Level          float64
+	RequestsPerSec float64
+	// ErrorRate é o percentual de requisições com falha (incluindo iterações descartadas)
+	ErrorRate float64
+	P99       time.Duration
+	Passed    bool
+}
+
+// CapacityResult contém o resultado da busca automática de capacidade
+type CapacityResult struct {
+	// Mode é "concurrency" ou "rate"
+	Mode   string
+	Points []CapacityPoint
+	// MaxSustainable é o ponto aprovado com maior vazão; nil se nenhum nível atendeu ao SLO
+	MaxSustainable *CapacityPoint
+	// Knee é o ponto em que a vazão deixa de crescer proporcionalmente à carga
+	Knee *CapacityPoint
 }
@@ -0,0 +1,249 @@
+// Gráficos do relatório HTML, desenhados em SVG a partir do relatório JSON
+// incorporado à página, sem bibliotecas externas
+(function () {
+  "use strict";
+
+  var report = JSON.parse(document.getElementById("report-data").textContent);
+  var tooltip = document.getElementById("tooltip");
+  var SVG = "http://www.w3.org/2000/svg";
+  var margin = { top: 12, right: 16, bottom: 28, left: 56 };
+  var colors = { primary: "#2f6fde", error: "#e03131", p50: "#2f9e44", p95: "#e8890c", p99: "#e03131" };
+
+  // formatMs formata milissegundos com a unidade mais legível
+  function formatMs(ms) {
+    if (ms >= 1000) return (ms / 1000).toFixed(2) + " s";
+    if (ms >= 1) return ms.toFixed(2) + " ms";
+    return Math.round(ms * 1000) + " µs";
+  }
+
+  function formatNumber(value) {
+    return value.toLocaleString("pt-BR", { maximumFractionDigits: 2 });
+  }
+
+  function formatPercent(value) {
+    return formatNumber(value) + "%";
+  }
+
+  function formatSeconds(value) {
+    return formatNumber(value) + "s";
+  }
+
+  function element(name, attributes, parent) {
+    var node = document.createElementNS(SVG, name);
+    for (var key in attributes) node.setAttribute(key, attributes[key]);
+    if (parent) parent.appendChild(node);
+    return node;
+  }
+
+  function text(content, attributes, parent) {
+    var node = element("text", attributes, parent);
+    node.textContent = content;
+    return node;
+  }
+
+  function showTooltip(event, lines) {
+    tooltip.innerHTML = "";
+    lines.forEach(function (line) {
+      var row = document.createElement("div");
+      row.textContent = line;
+      tooltip.appendChild(row);
+    });
+    tooltip.hidden = false;
+    var x = event.clientX + 14;
+    if (x + tooltip.offsetWidth > window.innerWidth) x = event.clientX - tooltip.offsetWidth - 14;
+    tooltip.style.left = x + "px";
+    tooltip.style.top = event.clientY + 14 + "px";
+  }
+
+  function hideTooltip() {
+    tooltip.hidden = true;
+  }
+
+  // niceMax arredonda o maior valor do eixo para um número legível
+  function niceMax(value) {
+    if (!(value > 0)) return 1;
+    var magnitude = Math.pow(10, Math.floor(Math.log10(value)));
+    var steps = [1, 2, 2.5, 5, 10];
+    for (var i = 0; i < steps.length; i++) {
+      if (steps[i] * magnitude >= value) return steps[i] * magnitude;
+    }
+    return 10 * magnitude;
+  }
+
+  // chart desenha um gráfico de colunas ou de linhas no contêiner. options:
+  // labels (eixo x), series ([{name, color, values}]), type ("bar" ou
+  // "line"), format (valores do eixo y) e describe (título da dica de um ponto)
+  function chart(container, options) {
+    var hidden = {};
+
+    function draw() {
+      container.innerHTML = "";
+      var width = container.clientWidth;
+      var height = container.clientHeight;
+      var plotWidth = width - margin.left - margin.right;
+      var plotHeight = height - margin.top - margin.bottom;
+      var svg = element("svg", { viewBox: "0 0 " + width + " " + height, role: "img" }, container);
+      var count = options.labels.length;
+
+      if (count === 0) {
+        text("Sem dados", { x: width / 2, y: height / 2, "text-anchor": "middle", class: "empty" }, svg);
+        return;
+      }
+
+      var visible = options.series.filter(function (serie) { return !hidden[serie.name]; });
+      var max = niceMax(Math.max.apply(null, visible.map(function (serie) {
+        return Math.max.apply(null, serie.values.concat([0]));
+      }).concat([0])));
+
+      var plot = element("g", { transform: "translate(" + margin.left + "," + margin.top + ")" }, svg);
+
+      // Grade e eixo y
+      for (var i = 0; i <= 4; i++) {
+        var y = plotHeight - (plotHeight * i) / 4;
+        element("line", { x1: 0, x2: plotWidth, y1: y, y2: y, class: i === 0 ? "axis" : "grid" }, plot);
+        text(options.format((max * i) / 4), { x: -8, y: y + 4, "text-anchor": "end" }, plot);
+      }
+
+      var band = plotWidth / count;
+      var xOf = options.type === "bar"
+        ? function (index) { return index * band + band / 2; }
+        : function (index) { return count === 1 ? plotWidth / 2 : (plotWidth * index) / (count - 1); };
+      var yOf = function (value) { return plotHeight - (plotHeight * value) / max; };
+
+      // Rótulos do eixo x, espaçados para não se sobreporem
+      var every = Math.max(1, Math.ceil(count / Math.max(1, Math.floor(plotWidth / 70))));
+      for (var index = 0; index < count; index += every) {
+        text(options.labels[index], { x: xOf(index), y: plotHeight + 18, "text-anchor": "middle" }, plot);
+      }
+
+      if (options.type === "bar") {
+        var serie = options.series[0];
+        serie.values.forEach(function (value, index) {
+          var column = element("rect", {
+            x: index * band + 1, width: Math.max(1, band - 2),
+            y: yOf(value), height: plotHeight - yOf(value),
+            fill: serie.color, class: "column"
+          }, plot);
+          column.addEventListener("mousemove", function (event) {
+            showTooltip(event, [options.describe(index), serie.name + ": " + options.format(value)]);
+          });
+          column.addEventListener("mouseleave", hideTooltip);
+        });
+      } else {
+        visible.forEach(function (serie) {
+          var points = serie.values.map(function (value, index) { return xOf(index) + "," + yOf(value); });
+          element("polyline", { points: points.join(" "), fill: "none", stroke: serie.color, "stroke-width": 2 }, plot);
+          if (count <= 40) {
+            serie.values.forEach(function (value, index) {
+              element("circle", { cx: xOf(index), cy: yOf(value), r: 2.5, fill: serie.color }, plot);
+            });
+          }
+        });
+
+        // A dica mostra o valor de todas as séries no ponto mais próximo do cursor
+        var guide = element("line", { y1: 0, y2: plotHeight, class: "guide", visibility: "hidden" }, plot);
+        var overlay = element("rect", { width: plotWidth, height: plotHeight, fill: "transparent" }, plot);
+        overlay.addEventListener("mousemove", function (event) {
+          var box = overlay.getBoundingClientRect();
+          var position = ((event.clientX - box.left) / box.width) * plotWidth;
+          var index = count === 1 ? 0 : Math.round((position / plotWidth) * (count - 1));
+          index = Math.min(count - 1, Math.max(0, index));
+          guide.setAttribute("x1", xOf(index));
+          guide.setAttribute("x2", xOf(index));
+          guide.setAttribute("visibility", "visible");
+          showTooltip(event, [options.describe(index)].concat(visible.map(function (serie) {
+            return serie.name + ": " + options.format(serie.values[index]);
+          })));
+        });
+        overlay.addEventListener("mouseleave", function () {
+          guide.setAttribute("visibility", "hidden");
+          hideTooltip();
+        });
+      }
+    }
+
+    // Com várias séries, a legenda permite ocultar cada uma
+    if (options.series.length > 1) {
+      var legend = document.createElement("div");
+      legend.className = "legend";
+      options.series.forEach(function (serie) {
+        var button = document.createElement("button");
+        button.type = "button";
+        button.innerHTML = '<i style="background:' + serie.color + '"></i>';
+        button.appendChild(document.createTextNode(serie.name));
+        button.addEventListener("click", function () {
+          hidden[serie.name] = !hidden[serie.name];
+          button.classList.toggle("off", hidden[serie.name]);
+          draw();
+        });
+        legend.appendChild(button);
+      });
+      container.parentNode.appendChild(legend);
+    }
+
+    draw();
+    window.addEventListener("resize", draw);
+  }
+
+  function pluck(items, field) {
+    return items.map(function (item) { return item[field]; });
+  }
+
+  var distribution = report.latency.distribution;
+  var curve = report.latency.percentile_curve;
+  var series = report.time_series;
+  var offsets = pluck(series, "offset_seconds").map(formatSeconds);
+
+  var charts = {
+    histogram: {
+      type: "bar",
+      labels: pluck(distribution, "lower_ms").map(formatMs),
+      series: [{ name: "Requisições", color: colors.primary, values: pluck(distribution, "count") }],
+      format: formatNumber,
+      describe: function (i) { return formatMs(distribution[i].lower_ms) + " – " + formatMs(distribution[i].upper_ms); }
+    },
+    percentiles: {
+      type: "line",
+      labels: pluck(curve, "percentile").map(function (p) { return "p" + p; }),
+      series: [{ name: "Latência", color: colors.primary, values: pluck(curve, "latency_ms") }],
+      format: formatMs,
+      describe: function (i) { return "Percentil " + formatNumber(curve[i].percentile); }
+    },
+    rps: {
+      type: "line",
+      labels: offsets,
+      series: [{ name: "req/s", color: colors.primary, values: pluck(series, "requests_per_sec") }],
+      format: formatNumber,
+      describe: function (i) { return "Tempo " + offsets[i]; }
+    },
+    errors: {
+      type: "line",
+      labels: offsets,
+      series: [{ name: "Erros", color: colors.error, values: pluck(series, "error_rate") }],
+      format: formatPercent,
+      describe: function (i) { return "Tempo " + offsets[i]; }
+    },
+    latency: {
+      type: "line",
+      labels: offsets,
+      series: [
+        { name: "p50", color: colors.p50, values: pluck(series, "p50_ms") },
+        { name: "p95", color: colors.p95, values: pluck(series, "p95_ms") },
+        { name: "p99", color: colors.p99, values: pluck(series, "p99_ms") }
+      ],
+      format: formatMs,
+      describe: function (i) { return "Tempo " + offsets[i]; }
+    },
+    inflight: {
+      type: "line",
+      labels: offsets,
+      series: [{ name: "Em andamento", color: colors.primary, values: pluck(series, "in_flight") }],
+      format: formatNumber,
+      describe: function (i) { return "Tempo " + offsets[i]; }
+    }
+  };
+
+  Array.prototype.forEach.call(document.querySelectorAll("[data-chart]"), function (container) {
+    chart(container, charts[container.getAttribute("data-chart")]);
+  });
+})();
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// PrintCapacityPoint exibe uma medição da busca de capacidade assim que
+// concluída; unit é a unidade do nível de carga, como "usuários" ou "req/s"
+func (f *Formatter) PrintCapacityPoint(unit string, point models.CapacityPoint) {
+	status := "✅"
+	if !point.Passed {
+		status = "❌"
+	}
+
+	fmt.Fprintf(f.out, "%s %s | 🚀 %.2f req/s | ❌ %.2f%% erros | ⏱️  p99 %v\n",
+		status, formatLevel(unit, point.Level), point.RequestsPerSec, point.ErrorRate, point.P99.Round(time.Millisecond))
+}
+
+// PrintCapacityReport exibe o resultado consolidado da busca de capacidade,
+// com os níveis de carga na unidade informada
+func (f *Formatter) PrintCapacityReport(result *models.CapacityResult, unit string) {
+	fmt.Fprintln(f.out, "\n"+strings.Repeat("=", 60))
+	fmt.Fprintln(f.out, "                 RELATÓRIO DE CAPACIDADE")
+	fmt.Fprintln(f.out, strings.Repeat("=", 60))
+
+	if len(result.Points) == 0 {
+		fmt.Fprintln(f.out, "❌ Nenhuma medição concluída")
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n📈 CURVA DE CARGA:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 60))
+	fmt.Fprintf(f.out, "%-18s %12s %10s %12s %6s\n", "Nível", "req/s", "Erros", "p99", "SLO")
+
+	maxRPS := 0.0
+	for _, point := range result.Points {
+		if point.RequestsPerSec > maxRPS {
+			maxRPS = point.RequestsPerSec
+		}
+	}
+
+	for _, point := range result.Points {
+		status := "✅"
+		if !point.Passed {
+			status = "❌"
+		}
+
+		barLength := 0
+		if maxRPS > 0 {
+			barLength = int(point.RequestsPerSec / maxRPS * 20)
+		}
+
+		fmt.Fprintf(f.out, "%-18s %12.2f %9.2f%% %12v %6s %s\n",
+			formatLevel(unit, point.Level), point.RequestsPerSec, point.ErrorRate,
+			point.P99.Round(time.Millisecond), status, strings.Repeat("█", barLength))
+	}
+
+	fmt.Fprintln(f.out, "\n🏁 RESULTADO:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+
+	if result.MaxSustainable == nil {
+		fmt.Fprintln(f.out, "❌ Nenhum nível de carga atendeu ao SLO")
+	} else {
+		fmt.Fprintf(f.out, "🚀 Vazão máxima sustentável: %.2f req/s (%s)\n",
+			result.MaxSustainable.RequestsPerSec, formatLevel(unit, result.MaxSustainable.Level))
+	}
+
+	if result.Knee != nil {
+		fmt.Fprintf(f.out, "📐 Joelho da curva: %s (%.2f req/s)\n",
+			formatLevel(unit, result.Knee.Level), result.Knee.RequestsPerSec)
+		fmt.Fprintln(f.out, "   💡 A partir deste ponto a vazão cresce menos que a carga aplicada")
+	}
+
+	fmt.Fprintln(f.out, strings.Repeat("=", 60))
+}
+
+// formatLevel formata o nível de carga na unidade da busca; níveis
+// fracionários, possíveis no modo de taxa, mantêm duas casas decimais
+func formatLevel(unit string, level float64) string {
+	if level == math.Trunc(level) {
+		return fmt.Sprintf("%.0f %s", level, unit)
+	}
+	return fmt.Sprintf("%.2f %s", level, unit)
+}
@@ -2,6 +2,8 @@ package report
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -10,55 +12,131 @@ import (
 )
 
 // Formatter é responsável por formatar e exibir relatórios
-type Formatter struct{}
+type Formatter struct {
+	out io.Writer
+}
 
-// NewFormatter cria uma nova instância do formatador
+// NewFormatter cria uma nova instância do formatador, que escreve na saída padrão
 func NewFormatter() *Formatter {
-	return &Formatter{}
+	return &Formatter{out: os.Stdout}
+}
+
+// SetOutput define onde o formatador escreve os relatórios
+func (f *Formatter) SetOutput(w io.Writer) {
+	f.out = w
 }
 
 // PrintReport exibe o relatório completo do teste de carga
 func (f *Formatter) PrintReport(result *models.StressTestResult) {
-	fmt.Println("\n" + strings.Repeat("=", 60))
-	fmt.Println("                 RELATÓRIO DE TESTE DE CARGA")
-	fmt.Println(strings.Repeat("=", 60))
+	fmt.Fprintln(f.out, "\n"+strings.Repeat("=", 60))
+	fmt.Fprintln(f.out, "                 RELATÓRIO DE TESTE DE CARGA")
+	fmt.Fprintln(f.out, strings.Repeat("=", 60))
 
 	f.printSummary(&result.Report)
+	if result.Config.Rate > 0 {
+		f.printOpenModelSummary(result.Config, &result.Report)
+	}
 	f.printStatusCodeDistribution(&result.Report)
 	f.printErrorCluster(&result.Report)
+	f.printFailedChecks(&result.Report)
 	f.printPerformanceMetrics(&result.Report)
-	f.printErrorSummary(result.Results)
+	f.printPhaseBreakdown(&result.Report)
+	f.printStages(&result.Report)
+	f.printEndpoints(&result.Report)
+	f.printJourneys(&result.Report)
+	f.printTokenRequests(&result.Report)
+	f.printTimeSeries(&result.Report)
+	f.printErrorSummary(&result.Report)
+	passed := f.printThresholds(result.Thresholds)
+
+	fmt.Fprintln(f.out, strings.Repeat("=", 60))
+	if passed {
+		fmt.Fprintln(f.out, "Teste concluído com sucesso!")
+	} else {
+		fmt.Fprintln(f.out, "Teste concluído com limites reprovados!")
+	}
+}
 
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println("Teste concluído com sucesso!")
+// printThresholds exibe a tabela de aprovação dos limites (SLOs) e indica se
+// todos foram atendidos
+func (f *Formatter) printThresholds(thresholds []models.ThresholdResult) bool {
+	if len(thresholds) == 0 {
+		return true
+	}
+
+	fmt.Fprintln(f.out, "\n🎯 LIMITES (SLOs):")
+	fmt.Fprintln(f.out, strings.Repeat("-", 60))
+	fmt.Fprintf(f.out, "   %-28s %16s   %s\n", "Limite", "Medido", "Resultado")
+
+	failed := 0
+	for _, threshold := range thresholds {
+		status := "✅ aprovado"
+		if !threshold.Passed {
+			status = "❌ reprovado"
+			failed++
+		}
+		fmt.Fprintf(f.out, "   %-28s %16s   %s\n", threshold.Expression, threshold.Actual, status)
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(f.out, "\n🚫 %d de %d limites reprovados\n", failed, len(thresholds))
+	} else {
+		fmt.Fprintf(f.out, "\n🏁 Todos os %d limites foram atendidos\n", len(thresholds))
+	}
+
+	return failed == 0
 }
 
 // printSummary exibe o resumo geral do teste
 func (f *Formatter) printSummary(report *models.TestReport) {
-	fmt.Println("\n📊 RESUMO GERAL:")
-	fmt.Println(strings.Repeat("-", 30))
-
-	fmt.Printf("⏱️  Tempo total de execução: %v\n", report.TotalTime.Round(time.Millisecond))
-	fmt.Printf("📈 Total de requisições: %d\n", report.TotalRequests)
-	fmt.Printf("✅ Requisições bem-sucedidas: %d\n", report.SuccessfulReqs)
-	fmt.Printf("❌ Requisições com falha: %d\n", report.FailedReqs)
+	fmt.Fprintln(f.out, "\n📊 RESUMO GERAL:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+
+	fmt.Fprintf(f.out, "⏱️  Tempo total de execução: %v\n", report.TotalTime.Round(time.Millisecond))
+	fmt.Fprintf(f.out, "📈 Total de requisições: %d\n", report.TotalRequests)
+	fmt.Fprintf(f.out, "✅ Requisições bem-sucedidas: %d\n", report.SuccessfulReqs)
+	fmt.Fprintf(f.out, "❌ Requisições com falha: %d\n", report.FailedReqs)
+	if report.CheckFailures > 0 {
+		fmt.Fprintf(f.out, "🧪 Reprovadas nas verificações: %d\n", report.CheckFailures)
+	}
 
 	if report.TotalRequests > 0 {
 		successRate := float64(report.SuccessfulReqs) / float64(report.TotalRequests) * 100
-		fmt.Printf("📊 Taxa de sucesso: %.2f%%\n", successRate)
+		fmt.Fprintf(f.out, "📊 Taxa de sucesso: %.2f%%\n", successRate)
+	}
+
+	fmt.Fprintf(f.out, "🚀 Requisições por segundo: %.2f req/s\n", report.RequestsPerSec)
+	fmt.Fprintf(f.out, "💾 Total de dados transferidos: %s\n", f.formatBytes(report.TotalDataTransfer))
+
+	if report.FeederExhausted {
+		fmt.Fprintln(f.out, "⚠️  Teste encerrado antes do previsto: os dados de entrada se esgotaram")
 	}
+}
+
+// printOpenModelSummary exibe as estatísticas do modo de taxa constante
+func (f *Formatter) printOpenModelSummary(config models.TestConfig, report *models.TestReport) {
+	fmt.Fprintln(f.out, "\n🎯 MODO DE TAXA CONSTANTE:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+
+	fmt.Fprintf(f.out, "🎯 Taxa planejada: %.2f req/s\n", config.Rate)
+	fmt.Fprintf(f.out, "🚀 Taxa obtida: %.2f req/s\n", report.RequestsPerSec)
+	fmt.Fprintf(f.out, "🚧 Limite de requisições em andamento: %d\n", config.Concurrency)
+	fmt.Fprintf(f.out, "🗑️  Iterações descartadas (limite atingido): %d\n", report.DroppedIterations)
+	fmt.Fprintf(f.out, "⏰ Iterações despachadas com atraso: %d\n", report.LateIterations)
 
-	fmt.Printf("🚀 Requisições por segundo: %.2f req/s\n", report.RequestsPerSec)
-	fmt.Printf("💾 Total de dados transferidos: %s\n", f.formatBytes(report.TotalDataTransfer))
+	if report.DroppedIterations > 0 {
+		fmt.Fprintln(f.out, "   💡 O serviço não acompanhou a taxa; aumente --concurrency ou reduza --rate")
+	}
+	fmt.Fprintln(f.out, "   ℹ️  Latências medidas a partir do instante planejado de envio")
 }
 
 // printStatusCodeDistribution exibe a distribuição de códigos de status HTTP
 func (f *Formatter) printStatusCodeDistribution(report *models.TestReport) {
-	fmt.Println("\n🔍 DISTRIBUIÇÃO DETALHADA DE CÓDIGOS HTTP:")
-	fmt.Println(strings.Repeat("-", 45))
+	fmt.Fprintln(f.out, "\n🔍 DISTRIBUIÇÃO DETALHADA DE CÓDIGOS HTTP:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 45))
 
 	if len(report.StatusCodes) == 0 {
-		fmt.Println("❌ Nenhum código de status registrado")
+		fmt.Fprintln(f.out, "❌ Nenhum código de status registrado")
 		return
 	}
 
@@ -131,14 +209,14 @@ type StatusCodeInfo struct {
 
 // printStatusCategory exibe uma categoria de códigos de status
 func (f *Formatter) printStatusCategory(category string, codes []StatusCodeInfo, totalRequests int) {
-	fmt.Printf("\n%s\n", category)
+	fmt.Fprintf(f.out, "\n%s\n", category)
 
 	for _, info := range codes {
 		percentage := float64(info.Count) / float64(totalRequests) * 100
 
 		// Formata a exibição com alinhamento melhor
-		fmt.Printf("  📋 HTTP %d - %s\n", info.Code, info.Description)
-		fmt.Printf("     📊 %d requisições (%.2f%%)\n", info.Count, percentage)
+		fmt.Fprintf(f.out, "  📋 HTTP %d - %s\n", info.Code, info.Description)
+		fmt.Fprintf(f.out, "     📊 %d requisições (%.2f%%)\n", info.Count, percentage)
 
 		// Adiciona barra de progresso visual para percentuais significativos
 		if percentage > 1.0 {
@@ -147,16 +225,16 @@ func (f *Formatter) printStatusCategory(category string, codes []StatusCodeInfo,
 				barLength = 20
 			}
 			bar := strings.Repeat("█", barLength)
-			fmt.Printf("     📈 [%s%s]\n", bar, strings.Repeat("░", 20-barLength))
+			fmt.Fprintf(f.out, "     📈 [%s%s]\n", bar, strings.Repeat("░", 20-barLength))
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
 	}
 }
 
 // printCategorySummary exibe um resumo consolidado por categoria
 func (f *Formatter) printCategorySummary(statusCodes map[int]int, totalRequests int) {
-	fmt.Println("\n📈 RESUMO POR CATEGORIA:")
-	fmt.Println(strings.Repeat("-", 30))
+	fmt.Fprintln(f.out, "\n📈 RESUMO POR CATEGORIA:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
 
 	summary := map[string]int{
 		"✅ Sucessos (2xx)":          0,
@@ -187,7 +265,7 @@ func (f *Formatter) printCategorySummary(statusCodes map[int]int, totalRequests
 	for category, count := range summary {
 		if count > 0 {
 			percentage := float64(count) / float64(totalRequests) * 100
-			fmt.Printf("%-25s %6d req (%.2f%%)\n", category, count, percentage)
+			fmt.Fprintf(f.out, "%-25s %6d req (%.2f%%)\n", category, count, percentage)
 		}
 	}
 }
@@ -252,32 +330,189 @@ func (f *Formatter) getDetailedStatusDescription(code int) string {
 
 // printPerformanceMetrics exibe métricas de performance detalhadas
 func (f *Formatter) printPerformanceMetrics(report *models.TestReport) {
-	fmt.Println("\n⚡ MÉTRICAS DE PERFORMANCE:")
-	fmt.Println(strings.Repeat("-", 30))
+	fmt.Fprintln(f.out, "\n⚡ MÉTRICAS DE PERFORMANCE:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
 
-	fmt.Printf("📊 Tempo médio de resposta: %v\n", report.AvgResponseTime.Round(time.Millisecond))
-	fmt.Printf("🏃 Tempo mínimo de resposta: %v\n", report.MinResponseTime.Round(time.Millisecond))
-	fmt.Printf("🐌 Tempo máximo de resposta: %v\n", report.MaxResponseTime.Round(time.Millisecond))
+	fmt.Fprintf(f.out, "📊 Tempo médio de resposta: %v\n", report.AvgResponseTime.Round(time.Millisecond))
+	fmt.Fprintf(f.out, "🏃 Tempo mínimo de resposta: %v\n", report.MinResponseTime.Round(time.Millisecond))
+	fmt.Fprintf(f.out, "🐌 Tempo máximo de resposta: %v\n", report.MaxResponseTime.Round(time.Millisecond))
 
 	// Calcula estatísticas adicionais
 	if report.MaxResponseTime > 0 {
 		variation := report.MaxResponseTime - report.MinResponseTime
-		fmt.Printf("📏 Variação de tempo: %v\n", variation.Round(time.Millisecond))
+		fmt.Fprintf(f.out, "📏 Variação de tempo: %v\n", variation.Round(time.Millisecond))
+		fmt.Fprintf(f.out, "📐 Desvio padrão: %v\n", report.StdDevResponseTime.Round(100*time.Microsecond))
 	}
+
+	if report.TotalRequests == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n🎯 PERCENTIS DE TEMPO DE RESPOSTA:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+	f.printPercentiles(report.Percentiles, report.MaxResponseTime)
 }
 
-// printErrorSummary exibe um resumo dos erros encontrados
-func (f *Formatter) printErrorSummary(results []models.RequestResult) {
-	errorCount := make(map[string]int)
-	totalErrors := 0
+// printPercentiles exibe os percentis com uma barra proporcional ao tempo máximo
+func (f *Formatter) printPercentiles(p models.Percentiles, max time.Duration) {
+	rows := []struct {
+		label string
+		value time.Duration
+	}{
+		{"p50", p.P50},
+		{"p75", p.P75},
+		{"p90", p.P90},
+		{"p95", p.P95},
+		{"p99", p.P99},
+		{"p99.9", p.P999},
+	}
+
+	for _, row := range rows {
+		barLength := 0
+		if max > 0 {
+			barLength = int(float64(row.value) / float64(max) * 20)
+		}
+		if barLength > 20 {
+			barLength = 20
+		}
+		fmt.Fprintf(f.out, "   %-6s %10v [%s%s]\n", row.label, row.value.Round(100*time.Microsecond),
+			strings.Repeat("█", barLength), strings.Repeat("░", 20-barLength))
+	}
+}
+
+// printPhaseBreakdown exibe as estatísticas de cada fase das requisições
+func (f *Formatter) printPhaseBreakdown(report *models.TestReport) {
+	if report.TotalRequests == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n🔬 TEMPO POR FASE DA REQUISIÇÃO:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 75))
+	fmt.Fprintf(f.out, "   %-16s %8s %10s %10s %10s %10s %10s\n", "Fase", "Qtd", "Média", "p50", "p90", "p95", "p99")
+
+	type phaseRow struct {
+		label string
+		stats models.PhaseStats
+	}
+
+	phases := []phaseRow{
+		{"🔎 DNS", report.Phases.DNS},
+		{"🔌 Conexão TCP", report.Phases.Connect},
+	}
+	// A fase de proxy só é exibida quando houve conexões por um proxy
+	if report.Phases.Proxy.Count > 0 {
+		phases = append(phases, phaseRow{"🛰️ Proxy", report.Phases.Proxy})
+	}
+	phases = append(phases,
+		phaseRow{"🔐 Handshake TLS", report.Phases.TLS},
+		phaseRow{"⏳ TTFB", report.Phases.TTFB},
+		phaseRow{"📥 Transferência", report.Phases.Transfer},
+	)
+
+	for _, phase := range phases {
+		stats := phase.stats
+		fmt.Fprintf(f.out, "   %-16s %8d %10v %10v %10v %10v %10v\n", phase.label, stats.Count,
+			stats.Avg.Round(10*time.Microsecond),
+			stats.Percentiles.P50.Round(10*time.Microsecond),
+			stats.Percentiles.P90.Round(10*time.Microsecond),
+			stats.Percentiles.P95.Round(10*time.Microsecond),
+			stats.Percentiles.P99.Round(10*time.Microsecond))
+	}
+
+	fmt.Fprintln(f.out, "   💡 DNS, conexão, proxy e TLS consideram apenas conexões novas; TTFB é a espera entre")
+	fmt.Fprintln(f.out, "      o envio da requisição e o primeiro byte da resposta")
+}
+
+// printStages exibe as estatísticas de cada estágio do perfil de carga
+func (f *Formatter) printStages(report *models.TestReport) {
+	if len(report.Stages) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n📶 ESTATÍSTICAS POR ESTÁGIO:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+	f.printGroups(report.Stages)
+}
 
-	for _, result := range results {
-		if result.Error != nil {
-			totalErrors++
-			// Agrupa erros similares por tipo
-			errorType := f.categorizeError(result.Error.Error())
-			errorCount[errorType]++
+// printEndpoints exibe as estatísticas de cada requisição configurada
+func (f *Formatter) printEndpoints(report *models.TestReport) {
+	if len(report.Endpoints) == 0 {
+		return
+	}
+
+	if report.Journeys != nil {
+		fmt.Fprintln(f.out, "\n🧭 ESTATÍSTICAS POR PASSO DO FLUXO:")
+	} else {
+		fmt.Fprintln(f.out, "\n🧭 ESTATÍSTICAS POR REQUISIÇÃO:")
+	}
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+	f.printGroups(report.Endpoints)
+}
+
+// printJourneys exibe as estatísticas das jornadas completas dos usuários virtuais
+func (f *Formatter) printJourneys(report *models.TestReport) {
+	journeys := report.Journeys
+	if journeys == nil {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n🚶 JORNADAS COMPLETAS:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+	if journeys.TotalRequests == 0 {
+		fmt.Fprintln(f.out, "   Nenhuma jornada concluída")
+		return
+	}
+
+	successRate := float64(journeys.SuccessfulReqs) / float64(journeys.TotalRequests) * 100
+	fmt.Fprintf(f.out, "   📈 %d jornadas (%.2f%% concluídas com sucesso) | 🚀 %.2f jornadas/s\n",
+		journeys.TotalRequests, successRate, journeys.RequestsPerSec)
+	fmt.Fprintf(f.out, "   📊 Tempo médio: %v | 🎯 p50: %v | p95: %v | p99: %v | 🐌 Máximo: %v\n",
+		journeys.AvgResponseTime.Round(100*time.Microsecond), journeys.Percentiles.P50.Round(100*time.Microsecond),
+		journeys.Percentiles.P95.Round(100*time.Microsecond), journeys.Percentiles.P99.Round(100*time.Microsecond),
+		journeys.MaxResponseTime.Round(100*time.Microsecond))
+	fmt.Fprintln(f.out, "   💡 Jornadas com falha são interrompidas no primeiro passo que falhou")
+}
+
+// printTokenRequests exibe as estatísticas das requisições de obtenção de
+// token OAuth2, que não fazem parte da carga do teste
+func (f *Formatter) printTokenRequests(report *models.TestReport) {
+	if report.TokenRequests == nil {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n🔑 OBTENÇÃO DE TOKENS (OAuth2):")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+	f.printGroups([]models.GroupReport{*report.TokenRequests})
+	fmt.Fprintln(f.out, "   💡 Requisições ao endpoint de tokens não entram nas demais estatísticas")
+}
+
+// printGroups exibe as estatísticas de um conjunto de grupos de requisições
+func (f *Formatter) printGroups(groups []models.GroupReport) {
+	for _, group := range groups {
+		fmt.Fprintf(f.out, "🔹 %s\n", group.Name)
+		if group.TotalRequests == 0 {
+			fmt.Fprintln(f.out, "   Nenhuma requisição neste grupo")
+			fmt.Fprintln(f.out)
+			continue
 		}
+
+		successRate := float64(group.SuccessfulReqs) / float64(group.TotalRequests) * 100
+		fmt.Fprintf(f.out, "   📈 %d requisições (%.2f%% de sucesso) | 🚀 %.2f req/s\n",
+			group.TotalRequests, successRate, group.RequestsPerSec)
+		fmt.Fprintf(f.out, "   📊 Tempo médio: %v | 🎯 p95: %v | p99: %v | 🐌 Máximo: %v\n",
+			group.AvgResponseTime.Round(100*time.Microsecond), group.Percentiles.P95.Round(100*time.Microsecond),
+			group.Percentiles.P99.Round(100*time.Microsecond), group.MaxResponseTime.Round(100*time.Microsecond))
+		fmt.Fprintln(f.out)
+	}
+}
+
+// printErrorSummary exibe um resumo dos erros encontrados
+func (f *Formatter) printErrorSummary(report *models.TestReport) {
+	// Os erros já chegam agrupados por categoria pelo executor
+	errorCount := report.ErrorCategories
+	totalErrors := 0
+	for _, count := range errorCount {
+		totalErrors += count
 	}
 
 	if len(errorCount) == 0 {
@@ -287,15 +522,15 @@ func (f *Formatter) printErrorSummary(results []models.RequestResult) {
 	// Se há muitos erros (mais que 20), não mostra detalhes individuais
 	// pois o cluster de erros já fornece informação consolidada
 	if totalErrors > 20 {
-		fmt.Println("\n🚨 RESUMO DE ERROS DE REDE:")
-		fmt.Println(strings.Repeat("-", 35))
-		fmt.Printf("📊 Total de %d erros de rede/conectividade detectados\n", totalErrors)
-		fmt.Println("   💡 Veja detalhes consolidados na seção 'CLUSTER DE ERROS' acima")
+		fmt.Fprintln(f.out, "\n🚨 RESUMO DE ERROS DE REDE:")
+		fmt.Fprintln(f.out, strings.Repeat("-", 35))
+		fmt.Fprintf(f.out, "📊 Total de %d erros de rede/conectividade detectados\n", totalErrors)
+		fmt.Fprintln(f.out, "   💡 Veja detalhes consolidados na seção 'CLUSTER DE ERROS' acima")
 		return
 	}
 
-	fmt.Println("\n🚨 RESUMO DETALHADO DE ERROS:")
-	fmt.Println(strings.Repeat("-", 35))
+	fmt.Fprintln(f.out, "\n🚨 RESUMO DETALHADO DE ERROS:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 35))
 
 	// Ordena erros por frequência (mais comuns primeiro)
 	type errorInfo struct {
@@ -313,43 +548,9 @@ func (f *Formatter) printErrorSummary(results []models.RequestResult) {
 	})
 
 	for _, err := range errors {
-		fmt.Printf("❌ %s\n", err.message)
-		fmt.Printf("   📊 %d ocorrências\n", err.count)
-		fmt.Println()
-	}
-}
-
-// categorizeError agrupa erros similares por categoria
-func (f *Formatter) categorizeError(errorMsg string) string {
-	errorMsg = strings.ToLower(errorMsg)
-
-	switch {
-	case strings.Contains(errorMsg, "connection refused"):
-		return "Erros de Conexão Recusada"
-	case strings.Contains(errorMsg, "timeout"):
-		return "Erros de Timeout"
-	case strings.Contains(errorMsg, "no such host"):
-		return "Erros de DNS/Host não encontrado"
-	case strings.Contains(errorMsg, "tls handshake"):
-		return "Erros de Handshake TLS/SSL"
-	case strings.Contains(errorMsg, "goaway") && strings.Contains(errorMsg, "enhance_your_calm"):
-		return "Erros de Rate Limiting (ENHANCE_YOUR_CALM)"
-	case strings.Contains(errorMsg, "stopped after") && strings.Contains(errorMsg, "redirects"):
-		return "Erros de Muitos Redirecionamentos"
-	case strings.Contains(errorMsg, "context deadline exceeded"):
-		return "Erros de Deadline/Timeout de Contexto"
-	case strings.Contains(errorMsg, "eof"):
-		return "Erros de Fim de Arquivo (EOF)"
-	case strings.Contains(errorMsg, "connection reset"):
-		return "Erros de Reset de Conexão"
-	case strings.Contains(errorMsg, "network is unreachable"):
-		return "Erros de Rede Inacessível"
-	default:
-		// Para outros erros, retorna uma versão simplificada
-		if len(errorMsg) > 80 {
-			return "Outros Erros de Rede"
-		}
-		return strings.Title(errorMsg)
+		fmt.Fprintf(f.out, "❌ %s\n", err.message)
+		fmt.Fprintf(f.out, "   📊 %d ocorrências\n", err.count)
+		fmt.Fprintln(f.out)
 	}
 }
 
@@ -370,8 +571,8 @@ func (f *Formatter) printErrorCluster(report *models.TestReport) {
 		return
 	}
 
-	fmt.Println("\n🚨 CLUSTER DE ERROS DETECTADOS:")
-	fmt.Println(strings.Repeat("-", 40))
+	fmt.Fprintln(f.out, "\n🚨 CLUSTER DE ERROS DETECTADOS:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 40))
 
 	// Ordena os códigos de erro para exibição consistente
 	var codes []int
@@ -393,8 +594,8 @@ func (f *Formatter) printErrorCluster(report *models.TestReport) {
 		icon := f.getStatusIcon(code)
 		description := f.getShortErrorDescription(code)
 
-		fmt.Printf("%s %d %s\n", icon, count, description)
-		fmt.Printf("   📊 %.2f%% do total | %.1f%% dos erros\n", percentage, errorPercentage)
+		fmt.Fprintf(f.out, "%s %d %s\n", icon, count, description)
+		fmt.Fprintf(f.out, "   📊 %.2f%% do total | %.1f%% dos erros\n", percentage, errorPercentage)
 
 		// Adiciona barra visual para erros mais significativos
 		if count > 1 {
@@ -404,17 +605,50 @@ func (f *Formatter) printErrorCluster(report *models.TestReport) {
 			}
 			if barLength > 0 {
 				bar := strings.Repeat("▓", barLength)
-				fmt.Printf("   📈 [%s%s]\n", bar, strings.Repeat("░", 15-barLength))
+				fmt.Fprintf(f.out, "   📈 [%s%s]\n", bar, strings.Repeat("░", 15-barLength))
 			}
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
 	}
 
-	fmt.Printf("🔢 Total de erros: %d/%d requisições (%.2f%%)\n",
+	fmt.Fprintf(f.out, "🔢 Total de erros: %d/%d requisições (%.2f%%)\n",
 		totalErrors, report.TotalRequests,
 		float64(totalErrors)/float64(report.TotalRequests)*100)
 }
 
+// printFailedChecks exibe as verificações em que as respostas foram
+// reprovadas, da mais frequente para a menos frequente
+func (f *Formatter) printFailedChecks(report *models.TestReport) {
+	if len(report.FailedChecks) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n🧪 VERIFICAÇÕES REPROVADAS:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 40))
+
+	checks := make([]string, 0, len(report.FailedChecks))
+	for check := range report.FailedChecks {
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		if report.FailedChecks[checks[i]] != report.FailedChecks[checks[j]] {
+			return report.FailedChecks[checks[i]] > report.FailedChecks[checks[j]]
+		}
+		return checks[i] < checks[j]
+	})
+
+	for _, check := range checks {
+		count := report.FailedChecks[check]
+		fmt.Fprintf(f.out, "❌ %s\n", check)
+		fmt.Fprintf(f.out, "   📊 %d reprovações (%.2f%% do total)\n", count, float64(count)/float64(report.TotalRequests)*100)
+	}
+
+	fmt.Fprintf(f.out, "\n🔢 Total reprovado: %d/%d requisições (%.2f%%)\n",
+		report.CheckFailures, report.TotalRequests,
+		float64(report.CheckFailures)/float64(report.TotalRequests)*100)
+	fmt.Fprintln(f.out, "   💡 Respostas recebidas que não atenderam às verificações; não são erros de rede")
+}
+
 // getShortErrorDescription retorna uma descrição curta para o cluster de erros
 func (f *Formatter) getShortErrorDescription(code int) string {
 	switch code {
@@ -534,7 +768,7 @@ func (f *Formatter) formatBytes(bytes int64) string {
 
 // PrintQuickSummary exibe um resumo rápido para uso em logs
 func (f *Formatter) PrintQuickSummary(report *models.TestReport) {
-	fmt.Printf("Resumo: %d/%d requisições bem-sucedidas (%.1f%%) em %v (%.2f req/s)\n",
+	fmt.Fprintf(f.out, "Resumo: %d/%d requisições bem-sucedidas (%.1f%%) em %v (%.2f req/s)\n",
 		report.SuccessfulReqs,
 		report.TotalRequests,
 		float64(report.SuccessfulReqs)/float64(report.TotalRequests)*100,
@@ -0,0 +1,173 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+
+	"stresstest/internal/models"
+)
+
+// htmlAssets contém o modelo, o estilo e os gráficos do relatório HTML, que
+// são incorporados ao arquivo gerado para que ele funcione sem conexão
+//
+//go:embed assets/report.html assets/report.css assets/report.js
+var htmlAssets embed.FS
+
+// htmlTemplate é o modelo do relatório HTML
+var htmlTemplate = template.Must(template.New("report.html").Funcs(template.FuncMap{
+	"ms":      formatHTMLMillis,
+	"percent": func(value float64) string { return strconv.FormatFloat(value, 'f', 2, 64) + "%" },
+	"number":  func(value float64) string { return strconv.FormatFloat(value, 'f', 2, 64) },
+	"bytes":   NewFormatter().formatBytes,
+}).ParseFS(htmlAssets, "assets/report.html"))
+
+// htmlView contém os dados exibidos pelo modelo do relatório HTML. As tabelas
+// são geradas no servidor; os gráficos são desenhados no navegador a partir do
+// relatório JSON incorporado
+type htmlView struct {
+	Title           string
+	Report          jsonReport
+	CSS             template.CSS
+	JS              template.JS
+	StatusCodes     []htmlCount
+	ErrorCategories []htmlCount
+	FailedChecks    []htmlCount
+	Phases          []htmlPhase
+	// Journeys e TokenRequests têm uma linha, ou nenhuma quando não se aplicam
+	Journeys      []jsonGroup
+	TokenRequests []jsonGroup
+}
+
+// htmlCount é uma linha das tabelas de contagens, com o percentual do total
+// de requisições
+type htmlCount struct {
+	Name    string
+	Detail  string
+	Class   string
+	Count   int
+	Percent float64
+}
+
+// htmlPhase é uma linha da tabela de fases das requisições
+type htmlPhase struct {
+	Name string
+	jsonPhase
+}
+
+// WriteHTML escreve o relatório do teste como uma página HTML independente,
+// com estilo e gráficos incorporados
+func WriteHTML(w io.Writer, result *models.StressTestResult, metadata Metadata) error {
+	css, err := htmlAssets.ReadFile("assets/report.css")
+	if err != nil {
+		return err
+	}
+	js, err := htmlAssets.ReadFile("assets/report.js")
+	if err != nil {
+		return err
+	}
+
+	report := newJSONReport(result, metadata)
+	view := htmlView{
+		Title:  htmlTitle(report.Config),
+		Report: report,
+		// Os arquivos incorporados são confiáveis e não passam pelo escape do modelo
+		CSS: template.CSS(css),
+		JS:  template.JS(js),
+		Phases: []htmlPhase{
+			{"🔎 DNS", report.Phases.DNS},
+			{"🔌 Conexão TCP", report.Phases.Connect},
+			{"🧭 Túnel do proxy", report.Phases.Proxy},
+			{"🔐 Handshake TLS", report.Phases.TLS},
+			{"⏳ TTFB", report.Phases.TTFB},
+			{"📥 Transferência", report.Phases.Transfer},
+		},
+	}
+
+	total := result.Report.TotalRequests
+	f := NewFormatter()
+	for code, count := range result.Report.StatusCodes {
+		name := strconv.Itoa(code)
+		if code == 0 {
+			name = "—"
+		}
+		view.StatusCodes = append(view.StatusCodes, htmlCount{
+			Name:    name,
+			Detail:  f.getStatusText(code),
+			Class:   statusClass(code),
+			Count:   count,
+			Percent: percentage(count, total),
+		})
+	}
+	sort.Slice(view.StatusCodes, func(i, j int) bool {
+		return view.StatusCodes[i].Name < view.StatusCodes[j].Name
+	})
+
+	if report.Journeys != nil {
+		view.Journeys = []jsonGroup{*report.Journeys}
+	}
+	if report.TokenRequests != nil {
+		view.TokenRequests = []jsonGroup{*report.TokenRequests}
+	}
+
+	view.ErrorCategories = sortedCounts(result.Report.ErrorCategories, total)
+	view.FailedChecks = sortedCounts(result.Report.FailedChecks, total)
+
+	return htmlTemplate.Execute(w, view)
+}
+
+// htmlTitle descreve o alvo do teste no título da página
+func htmlTitle(config jsonConfig) string {
+	switch {
+	case len(config.Flow) > 0:
+		return fmt.Sprintf("Fluxo de %d passos", len(config.Flow))
+	case len(config.Targets) > 0:
+		return fmt.Sprintf("%d requisições", len(config.Targets))
+	default:
+		return config.URL
+	}
+}
+
+// statusClass retorna a classe CSS da categoria de um código de status
+func statusClass(code int) string {
+	switch {
+	case code >= 200 && code < 300:
+		return "success"
+	case code >= 300 && code < 400:
+		return "redirect"
+	case code >= 400 && code < 500:
+		return "client-error"
+	default:
+		return "server-error"
+	}
+}
+
+// sortedCounts ordena contagens da maior para a menor, com o nome como desempate
+func sortedCounts(counts map[string]int, total int) []htmlCount {
+	rows := make([]htmlCount, 0, len(counts))
+	for name, count := range counts {
+		rows = append(rows, htmlCount{Name: name, Count: count, Percent: percentage(count, total)})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// formatHTMLMillis formata milissegundos com a unidade mais legível
+func formatHTMLMillis(ms float64) string {
+	switch {
+	case ms >= 1000:
+		return strconv.FormatFloat(ms/1000, 'f', 2, 64) + " s"
+	case ms >= 1:
+		return strconv.FormatFloat(ms, 'f', 2, 64) + " ms"
+	default:
+		return strconv.FormatFloat(ms*1000, 'f', 0, 64) + " µs"
+	}
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestWriteHTML(t *testing.T) {
+	result := &models.StressTestResult{
+		Config: models.TestConfig{URL: "http://localhost:8080/<api>", Concurrency: 2},
+		Report: models.TestReport{
+			TotalRequests:       3,
+			SuccessfulReqs:      2,
+			FailedReqs:          1,
+			StatusCodes:         map[int]int{200: 2, 0: 1},
+			ErrorCategories:     map[string]int{"Erros de Timeout": 1},
+			LatencyDistribution: []models.HistogramBucket{{Lower: time.Millisecond, Upper: 2 * time.Millisecond, Count: 3}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, result, Metadata{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	page := buf.String()
+
+	for _, expected := range []string{
+		`<script type="application/json" id="report-data">`,
+		`"schema_version":1`,
+		`<span class="badge success">200</span>`,
+		"Erros de Timeout",
+		"http://localhost:8080/&lt;api&gt;",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Expected the page to contain %q", expected)
+		}
+	}
+
+	// A página deve funcionar sem conexão: nenhum recurso externo é carregado
+	for _, external := range []string{"<script src", "<link", "@import"} {
+		if strings.Contains(page, external) {
+			t.Errorf("Expected no external resources, found %q", external)
+		}
+	}
+}
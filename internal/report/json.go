@@ -0,0 +1,401 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+	"stresstest/internal/version"
+)
+
+// JSONSchemaVersion identifica o formato do relatório JSON. Campos novos podem
+// ser acrescentados na mesma versão; remoções e mudanças de significado ou de
+// unidade incrementam a versão
+const JSONSchemaVersion = 1
+
+// Metadata descreve a execução que gerou o relatório
+type Metadata struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// jsonReport é o relatório JSON. Durações são expressas em milissegundos e
+// taxas em percentual; listas vazias são escritas como [] e seções que não se
+// aplicam ao teste, como null
+type jsonReport struct {
+	SchemaVersion   int             `json:"schema_version"`
+	Metadata        jsonMetadata    `json:"metadata"`
+	Config          jsonConfig      `json:"config"`
+	Summary         jsonSummary     `json:"summary"`
+	Latency         jsonLatency     `json:"latency"`
+	Phases          jsonPhases      `json:"phases"`
+	StatusCodes     map[string]int  `json:"status_codes"`
+	ErrorCategories map[string]int  `json:"error_categories"`
+	FailedChecks    map[string]int  `json:"failed_checks"`
+	Stages          []jsonGroup     `json:"stages"`
+	Endpoints       []jsonGroup     `json:"endpoints"`
+	Journeys        *jsonGroup      `json:"journeys"`
+	TokenRequests   *jsonGroup      `json:"token_requests"`
+	Thresholds      []jsonThreshold `json:"thresholds"`
+	TimeSeries      []jsonInterval  `json:"time_series"`
+}
+
+type jsonMetadata struct {
+	Tool       string    `json:"tool"`
+	Version    string    `json:"version"`
+	BuildTime  string    `json:"build_time,omitempty"`
+	GoVersion  string    `json:"go_version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	Hostname   string    `json:"hostname,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type jsonConfig struct {
+	// Mode é "closed" (concorrência fixa), "open" (taxa constante) ou "stages"
+	Mode        string       `json:"mode"`
+	URL         string       `json:"url,omitempty"`
+	Method      string       `json:"method,omitempty"`
+	Requests    int          `json:"requests"`
+	Concurrency int          `json:"concurrency"`
+	DurationMs  float64      `json:"duration_ms"`
+	Rate        float64      `json:"rate"`
+	Stages      []jsonStage  `json:"stages"`
+	Targets     []jsonTarget `json:"targets"`
+	Flow        []jsonTarget `json:"flow"`
+	Thresholds  []string     `json:"thresholds"`
+}
+
+type jsonStage struct {
+	DurationMs float64 `json:"duration_ms"`
+	Target     int     `json:"target"`
+}
+
+type jsonTarget struct {
+	Name   string `json:"name,omitempty"`
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url"`
+	Weight int    `json:"weight,omitempty"`
+}
+
+type jsonSummary struct {
+	TotalTimeMs       float64 `json:"total_time_ms"`
+	TotalRequests     int     `json:"total_requests"`
+	SuccessfulReqs    int     `json:"successful_requests"`
+	FailedReqs        int     `json:"failed_requests"`
+	SuccessRate       float64 `json:"success_rate"`
+	ErrorRate         float64 `json:"error_rate"`
+	CheckFailures     int     `json:"check_failures"`
+	RequestsPerSec    float64 `json:"requests_per_sec"`
+	BytesReceived     int64   `json:"bytes_received"`
+	DroppedIterations int     `json:"dropped_iterations"`
+	LateIterations    int     `json:"late_iterations"`
+	FeederExhausted   bool    `json:"feeder_exhausted"`
+	// ThresholdsPassed é verdadeiro quando todos os limites foram atendidos (ou
+	// nenhum foi configurado)
+	ThresholdsPassed bool `json:"thresholds_passed"`
+}
+
+type jsonPercentiles struct {
+	P50  float64 `json:"p50"`
+	P75  float64 `json:"p75"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	P999 float64 `json:"p99_9"`
+}
+
+type jsonLatency struct {
+	AvgMs         float64         `json:"avg_ms"`
+	MinMs         float64         `json:"min_ms"`
+	MaxMs         float64         `json:"max_ms"`
+	StdDevMs      float64         `json:"stddev_ms"`
+	PercentilesMs jsonPercentiles `json:"percentiles_ms"`
+	// Distribution agrupa as latências em faixas de largura logarítmica
+	Distribution []jsonBucket `json:"distribution"`
+	// PercentileCurve contém a latência em uma sequência de percentis (0 a 100)
+	PercentileCurve []jsonPercentilePoint `json:"percentile_curve"`
+}
+
+type jsonBucket struct {
+	LowerMs float64 `json:"lower_ms"`
+	UpperMs float64 `json:"upper_ms"`
+	Count   int     `json:"count"`
+}
+
+type jsonPercentilePoint struct {
+	Percentile float64 `json:"percentile"`
+	LatencyMs  float64 `json:"latency_ms"`
+}
+
+type jsonPhase struct {
+	Count         int             `json:"count"`
+	AvgMs         float64         `json:"avg_ms"`
+	PercentilesMs jsonPercentiles `json:"percentiles_ms"`
+}
+
+type jsonPhases struct {
+	DNS      jsonPhase `json:"dns"`
+	Connect  jsonPhase `json:"connect"`
+	Proxy    jsonPhase `json:"proxy"`
+	TLS      jsonPhase `json:"tls"`
+	TTFB     jsonPhase `json:"ttfb"`
+	Transfer jsonPhase `json:"transfer"`
+}
+
+type jsonGroup struct {
+	Name           string          `json:"name"`
+	TotalRequests  int             `json:"total_requests"`
+	SuccessfulReqs int             `json:"successful_requests"`
+	FailedReqs     int             `json:"failed_requests"`
+	RequestsPerSec float64         `json:"requests_per_sec"`
+	AvgMs          float64         `json:"avg_ms"`
+	MaxMs          float64         `json:"max_ms"`
+	PercentilesMs  jsonPercentiles `json:"percentiles_ms"`
+}
+
+type jsonThreshold struct {
+	Expression string `json:"expression"`
+	Actual     string `json:"actual"`
+	Passed     bool   `json:"passed"`
+}
+
+type jsonInterval struct {
+	OffsetSeconds  float64 `json:"offset_seconds"`
+	Requests       int     `json:"requests"`
+	FailedReqs     int     `json:"failed_requests"`
+	RequestsPerSec float64 `json:"requests_per_sec"`
+	ErrorRate      float64 `json:"error_rate"`
+	P50Ms          float64 `json:"p50_ms"`
+	P95Ms          float64 `json:"p95_ms"`
+	P99Ms          float64 `json:"p99_ms"`
+	Bytes          int64   `json:"bytes"`
+	InFlight       int     `json:"in_flight"`
+}
+
+// WriteJSON escreve o relatório do teste no formato JSON versionado
+func WriteJSON(w io.Writer, result *models.StressTestResult, metadata Metadata) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(newJSONReport(result, metadata))
+}
+
+// newJSONReport converte o resultado do teste para o formato JSON
+func newJSONReport(result *models.StressTestResult, metadata Metadata) jsonReport {
+	report := result.Report
+	hostname, _ := os.Hostname()
+
+	out := jsonReport{
+		SchemaVersion: JSONSchemaVersion,
+		Metadata: jsonMetadata{
+			Tool:       "stresstest",
+			Version:    version.Version,
+			BuildTime:  version.BuildTime,
+			GoVersion:  runtime.Version(),
+			OS:         runtime.GOOS,
+			Arch:       runtime.GOARCH,
+			Hostname:   hostname,
+			StartedAt:  metadata.StartedAt,
+			FinishedAt: metadata.FinishedAt,
+		},
+		Config: newJSONConfig(result.Config),
+		Summary: jsonSummary{
+			TotalTimeMs:       metrics.Millis(report.TotalTime),
+			TotalRequests:     report.TotalRequests,
+			SuccessfulReqs:    report.SuccessfulReqs,
+			FailedReqs:        report.FailedReqs,
+			SuccessRate:       percentage(report.SuccessfulReqs, report.TotalRequests),
+			ErrorRate:         percentage(report.FailedReqs, report.TotalRequests),
+			CheckFailures:     report.CheckFailures,
+			RequestsPerSec:    round(report.RequestsPerSec),
+			BytesReceived:     report.TotalDataTransfer,
+			DroppedIterations: report.DroppedIterations,
+			LateIterations:    report.LateIterations,
+			FeederExhausted:   report.FeederExhausted,
+			ThresholdsPassed:  true,
+		},
+		Latency: jsonLatency{
+			AvgMs:           metrics.Millis(report.AvgResponseTime),
+			MinMs:           metrics.Millis(report.MinResponseTime),
+			MaxMs:           metrics.Millis(report.MaxResponseTime),
+			StdDevMs:        metrics.Millis(report.StdDevResponseTime),
+			PercentilesMs:   newJSONPercentiles(report.Percentiles),
+			Distribution:    make([]jsonBucket, 0, len(report.LatencyDistribution)),
+			PercentileCurve: make([]jsonPercentilePoint, 0, len(report.PercentileCurve)),
+		},
+		Phases: jsonPhases{
+			DNS:      newJSONPhase(report.Phases.DNS),
+			Connect:  newJSONPhase(report.Phases.Connect),
+			Proxy:    newJSONPhase(report.Phases.Proxy),
+			TLS:      newJSONPhase(report.Phases.TLS),
+			TTFB:     newJSONPhase(report.Phases.TTFB),
+			Transfer: newJSONPhase(report.Phases.Transfer),
+		},
+		StatusCodes:     make(map[string]int, len(report.StatusCodes)),
+		ErrorCategories: make(map[string]int, len(report.ErrorCategories)),
+		FailedChecks:    make(map[string]int, len(report.FailedChecks)),
+		Stages:          newJSONGroups(report.Stages),
+		Endpoints:       newJSONGroups(report.Endpoints),
+		Journeys:        newJSONGroup(report.Journeys),
+		TokenRequests:   newJSONGroup(report.TokenRequests),
+		Thresholds:      make([]jsonThreshold, 0, len(result.Thresholds)),
+		TimeSeries:      make([]jsonInterval, 0, len(report.TimeSeries)),
+	}
+
+	for _, bucket := range report.LatencyDistribution {
+		out.Latency.Distribution = append(out.Latency.Distribution, jsonBucket{
+			LowerMs: metrics.Millis(bucket.Lower),
+			UpperMs: metrics.Millis(bucket.Upper),
+			Count:   bucket.Count,
+		})
+	}
+	for _, point := range report.PercentileCurve {
+		out.Latency.PercentileCurve = append(out.Latency.PercentileCurve, jsonPercentilePoint{
+			Percentile: point.Percentile,
+			LatencyMs:  metrics.Millis(point.Latency),
+		})
+	}
+
+	// Requisições sem resposta são contadas com o código 0
+	for code, count := range report.StatusCodes {
+		out.StatusCodes[strconv.Itoa(code)] = count
+	}
+	for category, count := range report.ErrorCategories {
+		out.ErrorCategories[category] = count
+	}
+	for check, count := range report.FailedChecks {
+		out.FailedChecks[check] = count
+	}
+
+	for _, threshold := range result.Thresholds {
+		out.Thresholds = append(out.Thresholds, jsonThreshold{
+			Expression: threshold.Expression,
+			Actual:     threshold.Actual,
+			Passed:     threshold.Passed,
+		})
+		out.Summary.ThresholdsPassed = out.Summary.ThresholdsPassed && threshold.Passed
+	}
+
+	for _, interval := range report.TimeSeries {
+		out.TimeSeries = append(out.TimeSeries, jsonInterval{
+			OffsetSeconds:  interval.Offset.Seconds(),
+			Requests:       interval.Requests,
+			FailedReqs:     interval.FailedReqs,
+			RequestsPerSec: round(interval.RequestsPerSec),
+			ErrorRate:      round(interval.ErrorRate),
+			P50Ms:          metrics.Millis(interval.P50),
+			P95Ms:          metrics.Millis(interval.P95),
+			P99Ms:          metrics.Millis(interval.P99),
+			Bytes:          interval.Bytes,
+			InFlight:       interval.InFlight,
+		})
+	}
+
+	return out
+}
+
+// newJSONConfig descreve a configuração do teste, sem cabeçalhos, corpos ou
+// credenciais
+func newJSONConfig(config models.TestConfig) jsonConfig {
+	out := jsonConfig{
+		Mode:        "closed",
+		URL:         config.URL,
+		Method:      config.Method,
+		Requests:    config.Requests,
+		Concurrency: config.Concurrency,
+		DurationMs:  metrics.Millis(config.Duration),
+		Rate:        config.Rate,
+		Stages:      make([]jsonStage, 0, len(config.Stages)),
+		Targets:     make([]jsonTarget, 0, len(config.Targets)),
+		Flow:        make([]jsonTarget, 0, len(config.Flow)),
+		Thresholds:  append(make([]string, 0, len(config.Thresholds)), config.Thresholds...),
+	}
+
+	switch {
+	case config.Rate > 0:
+		out.Mode = "open"
+	case len(config.Stages) > 0:
+		out.Mode = "stages"
+	}
+
+	for _, stage := range config.Stages {
+		out.Stages = append(out.Stages, jsonStage{DurationMs: metrics.Millis(stage.Duration), Target: stage.Target})
+	}
+	for _, spec := range config.Targets {
+		out.Targets = append(out.Targets, jsonTarget{Name: spec.Name, Method: spec.Method, URL: spec.URL, Weight: spec.Weight})
+	}
+	for _, step := range config.Flow {
+		spec := step.Request
+		out.Flow = append(out.Flow, jsonTarget{Name: spec.Name, Method: spec.Method, URL: spec.URL})
+	}
+
+	return out
+}
+
+// newJSONGroups converte as estatísticas de um conjunto de grupos
+func newJSONGroups(groups []models.GroupReport) []jsonGroup {
+	out := make([]jsonGroup, 0, len(groups))
+	for i := range groups {
+		out = append(out, *newJSONGroup(&groups[i]))
+	}
+	return out
+}
+
+// newJSONGroup converte as estatísticas de um grupo; nil quando não se aplica
+func newJSONGroup(group *models.GroupReport) *jsonGroup {
+	if group == nil {
+		return nil
+	}
+	return &jsonGroup{
+		Name:           group.Name,
+		TotalRequests:  group.TotalRequests,
+		SuccessfulReqs: group.SuccessfulReqs,
+		FailedReqs:     group.FailedReqs,
+		RequestsPerSec: round(group.RequestsPerSec),
+		AvgMs:          metrics.Millis(group.AvgResponseTime),
+		MaxMs:          metrics.Millis(group.MaxResponseTime),
+		PercentilesMs:  newJSONPercentiles(group.Percentiles),
+	}
+}
+
+// newJSONPhase converte as estatísticas de uma fase das requisições
+func newJSONPhase(phase models.PhaseStats) jsonPhase {
+	return jsonPhase{
+		Count:         phase.Count,
+		AvgMs:         metrics.Millis(phase.Avg),
+		PercentilesMs: newJSONPercentiles(phase.Percentiles),
+	}
+}
+
+// newJSONPercentiles converte os percentis para milissegundos
+func newJSONPercentiles(p models.Percentiles) jsonPercentiles {
+	return jsonPercentiles{
+		P50:  metrics.Millis(p.P50),
+		P75:  metrics.Millis(p.P75),
+		P90:  metrics.Millis(p.P90),
+		P95:  metrics.Millis(p.P95),
+		P99:  metrics.Millis(p.P99),
+		P999: metrics.Millis(p.P999),
+	}
+}
+
+// percentage calcula o percentual de part em total, com duas casas decimais
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(part) / float64(total) * 100)
+}
+
+// round arredonda um valor para duas casas decimais
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestWriteJSON(t *testing.T) {
+	result := &models.StressTestResult{
+		Config: models.TestConfig{
+			URL:         "http://localhost:8080/",
+			Method:      "GET",
+			Concurrency: 10,
+			Rate:        100,
+			Headers:     http.Header{"Authorization": {"Bearer segredo"}},
+			Thresholds:  []string{"p95<250ms"},
+		},
+		Report: models.TestReport{
+			TotalRequests:   4,
+			SuccessfulReqs:  3,
+			FailedReqs:      1,
+			AvgResponseTime: 1500 * time.Microsecond,
+			Percentiles:     models.Percentiles{P95: 250 * time.Millisecond},
+			StatusCodes:     map[int]int{200: 3, 0: 1},
+			ErrorCategories: map[string]int{"timeout": 1},
+		},
+		Thresholds: []models.ThresholdResult{{Expression: "p95<250ms", Actual: "250ms", Passed: false}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, result, Metadata{StartedAt: time.Unix(0, 0)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("segredo")) {
+		t.Errorf("Expected headers to be omitted from the report")
+	}
+
+	var decoded struct {
+		SchemaVersion int `json:"schema_version"`
+		Config        struct {
+			Mode string `json:"mode"`
+		} `json:"config"`
+		Summary struct {
+			ErrorRate        float64 `json:"error_rate"`
+			ThresholdsPassed bool    `json:"thresholds_passed"`
+		} `json:"summary"`
+		Latency struct {
+			AvgMs         float64            `json:"avg_ms"`
+			PercentilesMs map[string]float64 `json:"percentiles_ms"`
+		} `json:"latency"`
+		StatusCodes map[string]int `json:"status_codes"`
+		Stages      []interface{}  `json:"stages"`
+		Journeys    interface{}    `json:"journeys"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	if decoded.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", JSONSchemaVersion, decoded.SchemaVersion)
+	}
+	if decoded.Config.Mode != "open" {
+		t.Errorf("Expected mode open, got %s", decoded.Config.Mode)
+	}
+	if decoded.Summary.ErrorRate != 25 {
+		t.Errorf("Expected error rate 25, got %v", decoded.Summary.ErrorRate)
+	}
+	if decoded.Summary.ThresholdsPassed {
+		t.Errorf("Expected thresholds_passed false")
+	}
+	if decoded.Latency.AvgMs != 1.5 {
+		t.Errorf("Expected avg 1.5ms, got %v", decoded.Latency.AvgMs)
+	}
+	if decoded.Latency.PercentilesMs["p95"] != 250 {
+		t.Errorf("Expected p95 250ms, got %v", decoded.Latency.PercentilesMs["p95"])
+	}
+	if decoded.StatusCodes["0"] != 1 || decoded.StatusCodes["200"] != 3 {
+		t.Errorf("Expected status codes 200=3 and 0=1, got %v", decoded.StatusCodes)
+	}
+	if decoded.Stages == nil || len(decoded.Stages) != 0 {
+		t.Errorf("Expected empty stages array, got %v", decoded.Stages)
+	}
+	if decoded.Journeys != nil {
+		t.Errorf("Expected null journeys, got %v", decoded.Journeys)
+	}
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+)
+
+const (
+	// sparklineWidth é o número máximo de colunas de cada sparkline
+	sparklineWidth = 60
+
+	// maxTimeSeriesRows é o número máximo de linhas da tabela da série temporal
+	maxTimeSeriesRows = 30
+)
+
+// sparklineLevels são os caracteres usados para desenhar as sparklines
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// printTimeSeries exibe a evolução das métricas ao longo do teste
+func (f *Formatter) printTimeSeries(report *models.TestReport) {
+	series := report.TimeSeries
+	if len(series) < 2 {
+		return
+	}
+
+	fmt.Fprintln(f.out, "\n📉 EVOLUÇÃO AO LONGO DO TESTE:")
+	fmt.Fprintln(f.out, strings.Repeat("-", 30))
+
+	rps := make([]float64, len(series))
+	errorRate := make([]float64, len(series))
+	p95 := make([]float64, len(series))
+	inFlight := make([]float64, len(series))
+	for i, interval := range series {
+		rps[i] = interval.RequestsPerSec
+		errorRate[i] = interval.ErrorRate
+		p95[i] = float64(interval.P95)
+		inFlight[i] = float64(interval.InFlight)
+	}
+
+	fmt.Fprintf(f.out, "   🚀 %-13s %s\n", "req/s", sparkline(rps))
+	fmt.Fprintf(f.out, "   ❌ %-13s %s\n", "erros %", sparkline(errorRate))
+	fmt.Fprintf(f.out, "   🎯 %-13s %s\n", "p95", sparkline(p95))
+	fmt.Fprintf(f.out, "   🔄 %-13s %s\n", "em andamento", sparkline(inFlight))
+
+	// Em testes longos a tabela exibe apenas uma amostra dos intervalos
+	step := int(math.Ceil(float64(len(series)) / maxTimeSeriesRows))
+	fmt.Fprintln(f.out)
+	if step > 1 {
+		fmt.Fprintf(f.out, "   Exibindo 1 a cada %d intervalos\n", step)
+	}
+	fmt.Fprintf(f.out, "   %8s %10s %8s %10s %10s %10s %10s %6s\n",
+		"Tempo", "req/s", "Erros", "p50", "p95", "p99", "Dados", "Ativas")
+	for i := 0; i < len(series); i += step {
+		interval := series[i]
+		fmt.Fprintf(f.out, "   %8v %10.2f %7.2f%% %10v %10v %10v %10s %6d\n",
+			interval.Offset, interval.RequestsPerSec, interval.ErrorRate,
+			interval.P50.Round(100*time.Microsecond), interval.P95.Round(100*time.Microsecond),
+			interval.P99.Round(100*time.Microsecond), f.formatBytes(interval.Bytes), interval.InFlight)
+	}
+}
+
+// sparkline desenha uma série de valores em uma linha; séries maiores que
+// sparklineWidth são reduzidas pela média de cada grupo de pontos
+func sparkline(values []float64) string {
+	if len(values) > sparklineWidth {
+		reduced := make([]float64, sparklineWidth)
+		for i := range reduced {
+			from := i * len(values) / sparklineWidth
+			to := (i + 1) * len(values) / sparklineWidth
+			sum := 0.0
+			for _, value := range values[from:to] {
+				sum += value
+			}
+			reduced[i] = sum / float64(to-from)
+		}
+		values = reduced
+	}
+
+	max := 0.0
+	for _, value := range values {
+		max = math.Max(max, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range values {
+		level := 0
+		if max > 0 {
+			level = int(value / max * float64(len(sparklineLevels)-1))
+		}
+		builder.WriteRune(sparklineLevels[level])
+	}
+	return builder.String()
+}
+
+// WriteTimeSeriesCSV exporta a série temporal do teste em formato CSV
+func WriteTimeSeriesCSV(w io.Writer, series []models.IntervalMetrics) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"offset_seconds", "requests", "failed", "requests_per_sec", "error_rate_percent",
+		"p50_ms", "p95_ms", "p99_ms", "bytes", "in_flight",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, interval := range series {
+		record := []string{
+			strconv.FormatFloat(interval.Offset.Seconds(), 'f', 3, 64),
+			strconv.Itoa(interval.Requests),
+			strconv.Itoa(interval.FailedReqs),
+			strconv.FormatFloat(interval.RequestsPerSec, 'f', 2, 64),
+			strconv.FormatFloat(interval.ErrorRate, 'f', 2, 64),
+			strconv.FormatFloat(metrics.Millis(interval.P50), 'f', 3, 64),
+			strconv.FormatFloat(metrics.Millis(interval.P95), 'f', 3, 64),
+			strconv.FormatFloat(metrics.Millis(interval.P99), 'f', 3, 64),
+			strconv.FormatInt(interval.Bytes, 10),
+			strconv.Itoa(interval.InFlight),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,130 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"stresstest/internal/models"
+)
+
+// LoadFeeder carrega uma fonte de dados de um arquivo CSV (com cabeçalho) ou
+// JSON (lista de objetos) e valida a estratégia de leitura
+func LoadFeeder(path, strategy, onExhausted string) (models.Feeder, error) {
+	switch strategy {
+	case models.FeedSequential, models.FeedRandom, models.FeedUnique:
+	default:
+		return models.Feeder{}, fmt.Errorf("estratégia inválida %q: use %q, %q ou %q",
+			strategy, models.FeedSequential, models.FeedRandom, models.FeedUnique)
+	}
+
+	switch onExhausted {
+	case models.ExhaustedRecycle, models.ExhaustedStop:
+	default:
+		return models.Feeder{}, fmt.Errorf("comportamento ao esgotar inválido %q: use %q ou %q",
+			onExhausted, models.ExhaustedRecycle, models.ExhaustedStop)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.Feeder{}, err
+	}
+
+	var records []map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = parseCSV(data)
+	case ".json":
+		records, err = parseJSON(data)
+	default:
+		return models.Feeder{}, fmt.Errorf("%s: formato não suportado (use .csv ou .json)", path)
+	}
+	if err != nil {
+		return models.Feeder{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if len(records) == 0 {
+		return models.Feeder{}, fmt.Errorf("%s: nenhum registro encontrado", path)
+	}
+
+	return models.Feeder{
+		Name:        filepath.Base(path),
+		Records:     records,
+		Strategy:    strategy,
+		OnExhausted: onExhausted,
+	}, nil
+}
+
+// parseCSV interpreta um CSV cuja primeira linha contém os nomes das colunas
+func parseCSV(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if columns[i] == "" {
+			return nil, fmt.Errorf("coluna %d sem nome no cabeçalho", i+1)
+		}
+	}
+
+	var records []map[string]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]string, len(columns))
+		for i, column := range columns {
+			record[column] = row[i]
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseJSON interpreta uma lista de objetos. Valores que não são texto
+// (números, objetos, listas e null) mantêm a sua representação JSON
+func parseJSON(data []byte) ([]map[string]string, error) {
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("esperada uma lista de objetos JSON: %w", err)
+	}
+
+	records := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		record := make(map[string]string, len(object))
+		for key, raw := range object {
+			var text string
+			if len(raw) > 0 && raw[0] == '"' && json.Unmarshal(raw, &text) == nil {
+				record[key] = text
+				continue
+			}
+
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, raw); err != nil {
+				return nil, err
+			}
+			record[key] = compact.String()
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
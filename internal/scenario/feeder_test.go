@@ -0,0 +1,100 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestLoadFeeder(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected []map[string]string
+	}{
+		{
+			name:     "csv com BOM",
+			file:     "usuarios.csv",
+			content:  "\ufeffuser, password\nana, s3nha\n\"beto, o segundo\",x\n",
+			expected: []map[string]string{{"user": "ana", "password": "s3nha"}, {"user": "beto, o segundo", "password": "x"}},
+		},
+		{
+			name:    "json com valores que não são texto",
+			file:    "usuarios.json",
+			content: `[{"user": "ana", "id": 7, "ativo": true, "perfil": {"nivel": 2}, "tags": ["a", "b"], "extra": null}]`,
+			expected: []map[string]string{{
+				"user": "ana", "id": "7", "ativo": "true", "perfil": `{"nivel":2}`, "tags": `["a","b"]`, "extra": "null",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), tt.file, tt.content)
+			feeder, err := LoadFeeder(path, models.FeedRandom, models.ExhaustedStop)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if feeder.Name != tt.file || feeder.Strategy != models.FeedRandom || feeder.OnExhausted != models.ExhaustedStop {
+				t.Errorf("Expected feeder %s with random and stop, got %+v", tt.file, feeder)
+			}
+			if len(feeder.Records) != len(tt.expected) {
+				t.Fatalf("Expected %d records, got %d", len(tt.expected), len(feeder.Records))
+			}
+			for i, expected := range tt.expected {
+				record := feeder.Records[i]
+				if len(record) != len(expected) {
+					t.Errorf("Expected record %d to be %v, got %v", i, expected, record)
+				}
+				for key, value := range expected {
+					if record[key] != value {
+						t.Errorf("Expected %s=%q in record %d, got %q", key, value, i, record[key])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFeederErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		strategy  string
+		exhausted string
+		err       string
+	}{
+		{name: "linha curta", file: "dados.csv", content: "user,password\nana,1\nbeto\n", err: "record on line 3: wrong number of fields"},
+		{name: "linha longa", file: "dados.csv", content: "user,password\nana,1,extra\n", err: "record on line 2: wrong number of fields"},
+		{name: "coluna sem nome", file: "dados.csv", content: "user,,password\nana,1,2\n", err: "coluna 2 sem nome"},
+		{name: "csv vazio", file: "dados.csv", content: "", err: "nenhum registro encontrado"},
+		{name: "csv só com cabeçalho", file: "dados.csv", content: "user\n", err: "nenhum registro encontrado"},
+		{name: "json vazio", file: "dados.json", content: "[]", err: "nenhum registro encontrado"},
+		{name: "json sem conteúdo", file: "dados.json", content: "", err: "esperada uma lista de objetos JSON"},
+		{name: "json que não é lista", file: "dados.json", content: `{"user": "ana"}`, err: "esperada uma lista de objetos JSON"},
+		{name: "formato desconhecido", file: "dados.txt", content: "ana", err: "formato não suportado"},
+		{name: "estratégia inválida", file: "dados.csv", content: "user\nana\n", strategy: "roundrobin", err: `estratégia inválida "roundrobin"`},
+		{name: "esgotamento inválido", file: "dados.csv", content: "user\nana\n", exhausted: "wait", err: `comportamento ao esgotar inválido "wait"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, exhausted := tt.strategy, tt.exhausted
+			if strategy == "" {
+				strategy = models.FeedSequential
+			}
+			if exhausted == "" {
+				exhausted = models.ExhaustedRecycle
+			}
+
+			_, err := LoadFeeder(writeFile(t, t.TempDir(), tt.file, tt.content), strategy, exhausted)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
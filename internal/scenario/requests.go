@@ -0,0 +1,150 @@
+package scenario
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"stresstest/internal/httpclient"
+	"stresstest/internal/models"
+)
+
+// maxLineSize é o tamanho máximo de uma linha do arquivo de requisições
+const maxLineSize = 10 * 1024 * 1024
+
+// requestEntry é o formato de uma requisição nos arquivos de requisições
+type requestEntry struct {
+	Name    string            `json:"name"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	// Body aceita uma string, enviada como está, ou qualquer valor JSON,
+	// enviado serializado
+	Body   json.RawMessage `json:"body"`
+	Weight int             `json:"weight"`
+}
+
+// LoadRequestsFile carrega as requisições de um arquivo JSONL, uma por linha:
+//
+//	{"name": "produto", "method": "GET", "url": "https://api/products/1", "weight": 3}
+//	{"method": "POST", "url": "https://api/orders", "headers": {"Content-Type": "application/json"}, "body": {"item": 1}}
+func LoadRequestsFile(path string) ([]models.RequestSpec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var specs []models.RequestSpec
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry requestEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("linha %d: JSON inválido: %w", lineNumber, err)
+		}
+
+		spec, err := entry.spec()
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", lineNumber, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("nenhuma requisição encontrada em %s", path)
+	}
+
+	return specs, nil
+}
+
+// spec valida a entrada e a converte em uma requisição
+func (e requestEntry) spec() (models.RequestSpec, error) {
+	if err := ValidateURL(e.URL); err != nil {
+		return models.RequestSpec{}, err
+	}
+
+	if e.Weight < 0 {
+		return models.RequestSpec{}, fmt.Errorf("peso não pode ser negativo")
+	}
+
+	headers := make(http.Header)
+	for name, value := range e.Headers {
+		headers.Set(name, value)
+	}
+
+	body, err := decodeBody(e.Body)
+	if err != nil {
+		return models.RequestSpec{}, err
+	}
+
+	return models.RequestSpec{
+		Name:    e.Name,
+		Method:  strings.ToUpper(e.Method),
+		URL:     e.URL,
+		Headers: headers,
+		Body:    body,
+		Weight:  e.Weight,
+	}, nil
+}
+
+// decodeBody converte o corpo informado: strings são enviadas sem aspas e os
+// demais valores JSON como estão
+func decodeBody(raw json.RawMessage) ([]byte, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	if raw[0] == '"' {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, fmt.Errorf("corpo inválido: %w", err)
+		}
+		return []byte(text), nil
+	}
+
+	return []byte(raw), nil
+}
+
+// ValidateURL verifica se a URL da requisição é HTTP, HTTPS ou de um socket
+// UNIX. É usada tanto nos arquivos quanto na URL informada pela linha de comando
+func ValidateURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("URL é obrigatória")
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("URL inválida: %w", err)
+	}
+
+	if parsedURL.Scheme == "" {
+		return fmt.Errorf("URL %q deve incluir o esquema (http://, https:// ou unix://)", rawURL)
+	}
+
+	if parsedURL.Scheme == "unix" {
+		_, _, err := httpclient.SplitUnixURL(parsedURL)
+		return err
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("URL %q deve usar o esquema http://, https:// ou unix://", rawURL)
+	}
+
+	return nil
+}
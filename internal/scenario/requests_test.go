@@ -0,0 +1,88 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRequestsFile(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "requisicoes.jsonl", `{"name": "produto", "url": "https://api.exemplo.com/produtos/1", "weight": 3}
+
+{"method": "post", "url": "https://api.exemplo.com/pedidos", "headers": {"content-type": "application/json"}, "body": {"item": 1, "tags": ["a"]}}
+
+{"method": "PUT", "url": "https://api.exemplo.com/notas", "body": "texto \"livre\"", "weight": 0}
+{"url": "unix:///var/run/api.sock:/saude", "body": null}
+`)
+
+	specs, err := LoadRequestsFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(specs) != 4 {
+		t.Fatalf("Expected blank lines to be skipped and 4 requests loaded, got %d", len(specs))
+	}
+
+	if specs[0].Name != "produto" || specs[0].Method != "" || specs[0].Weight != 3 || specs[0].Body != nil {
+		t.Errorf("Expected produto with weight 3 and no body, got %+v", specs[0])
+	}
+	// Objetos são enviados como JSON; strings, sem as aspas
+	if specs[1].Method != "POST" || string(specs[1].Body) != `{"item": 1, "tags": ["a"]}` {
+		t.Errorf("Expected POST with the JSON object body, got %s %s", specs[1].Method, specs[1].Body)
+	}
+	if specs[1].Headers.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected the canonical Content-Type header, got %v", specs[1].Headers)
+	}
+	if string(specs[2].Body) != `texto "livre"` {
+		t.Errorf("Expected the string body without quotes, got %q", specs[2].Body)
+	}
+	// Peso zero equivale a 1 na seleção e é mantido como informado
+	if specs[2].Weight != 0 {
+		t.Errorf("Expected weight 0 to be kept, got %d", specs[2].Weight)
+	}
+	if specs[3].Body != nil {
+		t.Errorf("Expected a null body to be empty, got %q", specs[3].Body)
+	}
+}
+
+func TestLoadRequestsFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "URL inválida",
+			content: "{\"url\": \"https://api.exemplo.com\"}\n\n{\"url\": \"ftp://api.exemplo.com\"}\n",
+			err:     `linha 3: URL "ftp://api.exemplo.com" deve usar o esquema`,
+		},
+		{
+			name:    "URL ausente",
+			content: "{\"method\": \"GET\"}\n",
+			err:     "linha 1: URL é obrigatória",
+		},
+		{
+			name:    "peso negativo",
+			content: "{\"url\": \"https://api.exemplo.com\"}\n{\"url\": \"https://api.exemplo.com\", \"weight\": -2}\n",
+			err:     "linha 2: peso não pode ser negativo",
+		},
+		{
+			name:    "JSON inválido",
+			content: "{\"url\": \"https://api.exemplo.com\"\n",
+			err:     "linha 1: JSON inválido",
+		},
+		{
+			name:    "arquivo vazio",
+			content: "\n  \n",
+			err:     "nenhuma requisição encontrada",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRequestsFile(writeFile(t, t.TempDir(), "requisicoes.jsonl", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"stresstest/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario descreve um teste com várias requisições nomeadas e o mix de
+// tráfego entre elas, ou com a jornada de um usuário virtual (Flow). Arquivos
+// YAML e JSON são aceitos (JSON é um subconjunto de YAML)
+type Scenario struct {
+	Name string `yaml:"name"`
+	Load Load   `yaml:"load"`
+	// Order é "random" (padrão), sorteando conforme os pesos, ou "sequential"
+	Order string `yaml:"order"`
+	// Headers são aplicados a todas as requisições do cenário
+	Headers  map[string]string `yaml:"headers"`
+	Requests []Request         `yaml:"requests"`
+	// Flow substitui Requests por passos executados em ordem por cada usuário virtual
+	Flow []Step `yaml:"flow"`
+	// Feeders são fontes de dados cujas colunas viram variáveis dos templates
+	Feeders []FeederSource `yaml:"feeders"`
+	// Client descreve como os usuários virtuais se conectam ao serviço
+	Client Client `yaml:"client"`
+	// Auth descreve as credenciais aplicadas a todas as requisições
+	Auth *Auth `yaml:"auth"`
+	// Checks são as verificações das requisições sem verificações próprias
+	Checks *Checks `yaml:"checks"`
+	// Thresholds são os limites (SLOs) que aprovam o teste, como p95<250ms
+	Thresholds []string `yaml:"thresholds"`
+
+	// dir é o diretório do arquivo, base dos caminhos relativos das fontes de dados
+	dir string
+}
+
+// Client contém as opções do cliente HTTP do cenário
+type Client struct {
+	// Cookies mantém os cookies de cada usuário virtual entre as requisições
+	Cookies bool `yaml:"cookies"`
+	// Isolated dá a cada usuário virtual as próprias conexões e sessões TLS
+	Isolated bool `yaml:"isolated"`
+	TLS      TLS  `yaml:"tls"`
+	// Proxy é a URL do proxy; aceita variáveis de ambiente (${NOME}) para as credenciais
+	Proxy string `yaml:"proxy"`
+	DNS   DNS    `yaml:"dns"`
+	// H2C usa HTTP/2 sem TLS nas URLs http://
+	H2C bool `yaml:"h2c"`
+}
+
+// DNS contém a resolução de nomes do cenário
+type DNS struct {
+	// Resolve fixa endereços de destinos, no formato host:porta:endereço
+	Resolve    []string `yaml:"resolve"`
+	Server     string   `yaml:"server"`
+	RoundRobin bool     `yaml:"round_robin"`
+}
+
+// TLS contém a configuração TLS do cenário; os caminhos são relativos ao
+// arquivo do cenário
+type TLS struct {
+	Cert       string   `yaml:"cert"`
+	Key        string   `yaml:"key"`
+	CACert     string   `yaml:"cacert"`
+	Insecure   bool     `yaml:"insecure"`
+	ServerName string   `yaml:"server_name"`
+	MinVersion string   `yaml:"min_version"`
+	MaxVersion string   `yaml:"max_version"`
+	Ciphers    []string `yaml:"ciphers"`
+}
+
+// Auth descreve a autenticação do cenário; apenas uma forma deve ser
+// informada. Os valores aceitam variáveis de ambiente (${NOME})
+type Auth struct {
+	Bearer string `yaml:"bearer"`
+	Basic  *struct {
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+	} `yaml:"basic"`
+	OAuth2 *struct {
+		TokenURL     string   `yaml:"token_url"`
+		ClientID     string   `yaml:"client_id"`
+		ClientSecret string   `yaml:"client_secret"`
+		Scopes       []string `yaml:"scopes"`
+	} `yaml:"oauth2"`
+}
+
+// options converte a autenticação do cenário, expandindo as variáveis de ambiente
+func (a *Auth) options() (models.AuthOptions, error) {
+	var options []models.AuthOptions
+	if a.Bearer != "" {
+		options = append(options, models.AuthOptions{Type: models.AuthBearer, Token: os.ExpandEnv(a.Bearer)})
+	}
+	if a.Basic != nil {
+		options = append(options, models.AuthOptions{
+			Type:     models.AuthBasic,
+			Username: os.ExpandEnv(a.Basic.Username),
+			Password: os.ExpandEnv(a.Basic.Password),
+		})
+	}
+	if a.OAuth2 != nil {
+		options = append(options, models.AuthOptions{
+			Type:         models.AuthOAuth2,
+			TokenURL:     os.ExpandEnv(a.OAuth2.TokenURL),
+			ClientID:     os.ExpandEnv(a.OAuth2.ClientID),
+			ClientSecret: os.ExpandEnv(a.OAuth2.ClientSecret),
+			Scopes:       a.OAuth2.Scopes,
+		})
+	}
+
+	if len(options) != 1 {
+		return models.AuthOptions{}, fmt.Errorf("informe exatamente um de bearer, basic ou oauth2")
+	}
+	return options[0], nil
+}
+
+// FeederSource descreve uma fonte de dados CSV ou JSON do cenário
+type FeederSource struct {
+	File string `yaml:"file"`
+	// Strategy é "sequential" (padrão), "random" ou "unique"
+	Strategy string `yaml:"strategy"`
+	// Exhausted é "recycle" (padrão) ou "stop"
+	Exhausted string `yaml:"exhausted"`
+}
+
+// Load descreve a carga aplicada pelo cenário
+type Load struct {
+	Requests    int     `yaml:"requests"`
+	Concurrency int     `yaml:"concurrency"`
+	Duration    string  `yaml:"duration"`
+	Rate        float64 `yaml:"rate"`
+	Stages      []Stage `yaml:"stages"`
+}
+
+// Stage descreve um estágio do perfil de carga do cenário
+type Stage struct {
+	Duration string `yaml:"duration"`
+	Target   int    `yaml:"target"`
+}
+
+// Request descreve uma requisição nomeada do cenário
+type Request struct {
+	Name    string            `yaml:"name"`
+	Method  string            `yaml:"method"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	// Body aceita um texto, enviado como está, ou uma estrutura, enviada como JSON
+	Body   interface{} `yaml:"body"`
+	Weight int         `yaml:"weight"`
+	// Checks substitui as verificações do cenário para esta requisição
+	Checks *Checks `yaml:"checks"`
+}
+
+// Checks descreve as verificações aplicadas às respostas
+type Checks struct {
+	// Status são os códigos aceitos; vazio mantém a regra padrão (2xx)
+	Status       []int       `yaml:"status"`
+	BodyContains []string    `yaml:"body_contains"`
+	BodyRegex    []string    `yaml:"body_regex"`
+	JSON         []JSONCheck `yaml:"json"`
+	// Headers são os cabeçalhos que devem estar presentes
+	Headers    []string `yaml:"headers"`
+	MaxLatency string   `yaml:"max_latency"`
+	MinSize    int64    `yaml:"min_size"`
+	MaxSize    int64    `yaml:"max_size"`
+}
+
+// JSONCheck verifica um campo do corpo JSON; sem equals, basta o campo existir
+type JSONCheck struct {
+	Path   string  `yaml:"path"`
+	Equals *string `yaml:"equals"`
+}
+
+// Step descreve um passo do fluxo: uma requisição, que pode referenciar
+// variáveis com {{nome}}, e os valores extraídos da sua resposta
+type Step struct {
+	Request `yaml:",inline"`
+	Extract []Extract `yaml:"extract"`
+}
+
+// Extract descreve um valor extraído da resposta para a variável Var. Apenas
+// uma origem deve ser informada
+type Extract struct {
+	Var    string `yaml:"var"`
+	JSON   string `yaml:"json"`
+	Regex  string `yaml:"regex"`
+	Header string `yaml:"header"`
+}
+
+// LoadFile carrega um cenário de um arquivo YAML ou JSON
+func LoadFile(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenario Scenario
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("cenário inválido: %w", err)
+	}
+	scenario.dir = filepath.Dir(path)
+
+	return &scenario, nil
+}
+
+// Config converte o cenário na configuração do teste
+func (s *Scenario) Config() (models.TestConfig, error) {
+	config := models.TestConfig{
+		Requests:    s.Load.Requests,
+		Concurrency: s.Load.Concurrency,
+		Rate:        s.Load.Rate,
+		Headers:     make(http.Header),
+		TargetOrder: models.OrderRandom,
+		Thresholds:  s.Thresholds,
+		Client: models.ClientOptions{
+			CookieJar:           s.Client.Cookies,
+			IsolatedConnections: s.Client.Isolated,
+			TLS: models.TLSOptions{
+				CertFile:     s.path(s.Client.TLS.Cert),
+				KeyFile:      s.path(s.Client.TLS.Key),
+				CAFile:       s.path(s.Client.TLS.CACert),
+				Insecure:     s.Client.TLS.Insecure,
+				ServerName:   s.Client.TLS.ServerName,
+				MinVersion:   s.Client.TLS.MinVersion,
+				MaxVersion:   s.Client.TLS.MaxVersion,
+				CipherSuites: s.Client.TLS.Ciphers,
+			},
+			Proxy: os.ExpandEnv(s.Client.Proxy),
+			DNS: models.DNSOptions{
+				Resolve:    s.Client.DNS.Resolve,
+				Server:     s.Client.DNS.Server,
+				RoundRobin: s.Client.DNS.RoundRobin,
+			},
+			H2C: s.Client.H2C,
+		},
+	}
+
+	if s.Load.Duration != "" {
+		duration, err := time.ParseDuration(s.Load.Duration)
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("duração inválida %q", s.Load.Duration)
+		}
+		config.Duration = duration
+	}
+
+	for i, stage := range s.Load.Stages {
+		duration, err := time.ParseDuration(stage.Duration)
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("duração inválida no estágio %d: %q", i+1, stage.Duration)
+		}
+		config.Stages = append(config.Stages, models.Stage{Duration: duration, Target: stage.Target})
+	}
+
+	switch s.Order {
+	case "", models.OrderRandom:
+	case models.OrderSequential:
+		config.TargetOrder = models.OrderSequential
+	default:
+		return models.TestConfig{}, fmt.Errorf("ordem inválida %q: use %q ou %q", s.Order, models.OrderRandom, models.OrderSequential)
+	}
+
+	for name, value := range s.Headers {
+		config.Headers.Set(name, value)
+	}
+
+	if s.Checks != nil {
+		checks, err := s.Checks.options()
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("verificações: %w", err)
+		}
+		config.Checks = checks
+	}
+
+	if s.Auth != nil {
+		options, err := s.Auth.options()
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("autenticação: %w", err)
+		}
+		config.Auth = options
+	}
+
+	for _, source := range s.Feeders {
+		feeder, err := source.load(s.dir)
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("fonte de dados: %w", err)
+		}
+		config.Feeders = append(config.Feeders, feeder)
+	}
+
+	if len(s.Flow) > 0 {
+		if len(s.Requests) > 0 {
+			return models.TestConfig{}, fmt.Errorf("informe requests ou flow, não ambos")
+		}
+		return s.flowConfig(config)
+	}
+
+	if len(s.Requests) == 0 {
+		return models.TestConfig{}, fmt.Errorf("o cenário deve ter pelo menos uma requisição")
+	}
+
+	names := make(map[string]bool)
+	for i, request := range s.Requests {
+		spec, err := request.spec()
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("requisição %d: %w", i+1, err)
+		}
+
+		if spec.Name != "" {
+			if names[spec.Name] {
+				return models.TestConfig{}, fmt.Errorf("requisição %d: nome %q repetido", i+1, spec.Name)
+			}
+			names[spec.Name] = true
+		}
+
+		config.Targets = append(config.Targets, spec)
+	}
+
+	return config, nil
+}
+
+// load carrega a fonte de dados, aplicando os valores padrão
+func (f FeederSource) load(dir string) (models.Feeder, error) {
+	if f.File == "" {
+		return models.Feeder{}, fmt.Errorf("informe o arquivo (file)")
+	}
+
+	path := resolvePath(dir, f.File)
+
+	strategy := f.Strategy
+	if strategy == "" {
+		strategy = models.FeedSequential
+	}
+	exhausted := f.Exhausted
+	if exhausted == "" {
+		exhausted = models.ExhaustedRecycle
+	}
+
+	return LoadFeeder(path, strategy, exhausted)
+}
+
+// path resolve um caminho do cenário em relação ao diretório do arquivo
+func (s *Scenario) path(file string) string {
+	return resolvePath(s.dir, file)
+}
+
+// resolvePath resolve caminhos relativos em relação a dir; vazio permanece vazio
+func resolvePath(dir, file string) string {
+	if file == "" || filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(dir, file)
+}
+
+// flowConfig acrescenta os passos do fluxo à configuração
+func (s *Scenario) flowConfig(config models.TestConfig) (models.TestConfig, error) {
+	names := make(map[string]bool)
+	for i, step := range s.Flow {
+		spec, err := step.spec()
+		if err != nil {
+			return models.TestConfig{}, fmt.Errorf("passo %d: %w", i+1, err)
+		}
+
+		if spec.Name != "" {
+			if names[spec.Name] {
+				return models.TestConfig{}, fmt.Errorf("passo %d: nome %q repetido", i+1, spec.Name)
+			}
+			names[spec.Name] = true
+		}
+
+		flowStep := models.FlowStep{Request: spec}
+		for _, extract := range step.Extract {
+			extraction, err := extract.extraction()
+			if err != nil {
+				return models.TestConfig{}, fmt.Errorf("passo %d: %w", i+1, err)
+			}
+			flowStep.Extract = append(flowStep.Extract, extraction)
+		}
+
+		config.Flow = append(config.Flow, flowStep)
+	}
+
+	return config, nil
+}
+
+// extraction converte a extração do cenário, exigindo exatamente uma origem
+func (e Extract) extraction() (models.Extraction, error) {
+	if e.Var == "" {
+		return models.Extraction{}, fmt.Errorf("extração sem var")
+	}
+
+	var sources []models.Extraction
+	if e.JSON != "" {
+		sources = append(sources, models.Extraction{Var: e.Var, Source: models.ExtractJSON, Expression: e.JSON})
+	}
+	if e.Regex != "" {
+		sources = append(sources, models.Extraction{Var: e.Var, Source: models.ExtractRegex, Expression: e.Regex})
+	}
+	if e.Header != "" {
+		sources = append(sources, models.Extraction{Var: e.Var, Source: models.ExtractHeader, Expression: e.Header})
+	}
+
+	if len(sources) != 1 {
+		return models.Extraction{}, fmt.Errorf("extração %q deve informar exatamente um de json, regex ou header", e.Var)
+	}
+
+	return sources[0], nil
+}
+
+// spec valida a requisição do cenário e a converte
+func (r Request) spec() (models.RequestSpec, error) {
+	if err := ValidateURL(r.URL); err != nil {
+		return models.RequestSpec{}, err
+	}
+
+	if r.Weight < 0 {
+		return models.RequestSpec{}, fmt.Errorf("peso não pode ser negativo")
+	}
+
+	headers := make(http.Header)
+	for name, value := range r.Headers {
+		headers.Set(name, value)
+	}
+
+	var body []byte
+	switch value := r.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return models.RequestSpec{}, fmt.Errorf("corpo inválido: %w", err)
+		}
+		body = encoded
+	}
+
+	var checks *models.Checks
+	if r.Checks != nil {
+		var err error
+		if checks, err = r.Checks.options(); err != nil {
+			return models.RequestSpec{}, fmt.Errorf("verificações: %w", err)
+		}
+	}
+
+	return models.RequestSpec{
+		Name:    r.Name,
+		Method:  strings.ToUpper(r.Method),
+		URL:     r.URL,
+		Headers: headers,
+		Body:    body,
+		Weight:  r.Weight,
+		Checks:  checks,
+	}, nil
+}
+
+// options converte as verificações do cenário
+func (c *Checks) options() (*models.Checks, error) {
+	checks := &models.Checks{
+		Status:       c.Status,
+		BodyContains: c.BodyContains,
+		BodyRegex:    c.BodyRegex,
+		Headers:      c.Headers,
+		MinSize:      c.MinSize,
+		MaxSize:      c.MaxSize,
+	}
+
+	if c.MaxLatency != "" {
+		latency, err := time.ParseDuration(c.MaxLatency)
+		if err != nil {
+			return nil, fmt.Errorf("latência máxima inválida %q", c.MaxLatency)
+		}
+		checks.MaxLatency = latency
+	}
+
+	for _, check := range c.JSON {
+		if check.Path == "" {
+			return nil, fmt.Errorf("verificação JSON sem caminho (path)")
+		}
+		if check.Equals == nil {
+			checks.JSON = append(checks.JSON, models.JSONCheck{Path: check.Path, Exists: true})
+		} else {
+			checks.JSON = append(checks.JSON, models.JSONCheck{Path: check.Path, Value: *check.Equals})
+		}
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,336 @@
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// writeFile grava um arquivo no diretório e retorna o seu caminho
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// loadConfig carrega o cenário do arquivo e o converte na configuração do teste
+func loadConfig(t *testing.T, path string) (models.TestConfig, error) {
+	t.Helper()
+	loaded, err := LoadFile(path)
+	if err != nil {
+		return models.TestConfig{}, err
+	}
+	return loaded.Config()
+}
+
+func TestLoadFileYAMLAndJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "cenario.yaml",
+			content: `name: demo
+load:
+  duration: 30s
+  concurrency: 5
+order: sequential
+headers:
+  X-Origem: carga
+checks:
+  status: [200, 201]
+  max_latency: 250ms
+requests:
+  - name: listar
+    url: https://api.exemplo.com/produtos
+    weight: 3
+  - name: criar
+    method: post
+    url: https://api.exemplo.com/produtos
+    body:
+      nome: caneta
+    checks:
+      json:
+        - path: $.id
+        - path: $.nome
+          equals: caneta
+`,
+		},
+		{
+			name: "json",
+			file: "cenario.json",
+			content: `{
+  "name": "demo",
+  "load": {"duration": "30s", "concurrency": 5},
+  "order": "sequential",
+  "headers": {"X-Origem": "carga"},
+  "checks": {"status": [200, 201], "max_latency": "250ms"},
+  "requests": [
+    {"name": "listar", "url": "https://api.exemplo.com/produtos", "weight": 3},
+    {"name": "criar", "method": "post", "url": "https://api.exemplo.com/produtos",
+     "body": {"nome": "caneta"},
+     "checks": {"json": [{"path": "$.id"}, {"path": "$.nome", "equals": "caneta"}]}}
+  ]
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := LoadFile(writeFile(t, t.TempDir(), tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if loaded.Name != "demo" {
+				t.Errorf("Expected name demo, got %q", loaded.Name)
+			}
+
+			config, err := loaded.Config()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if config.Duration != 30*time.Second || config.Concurrency != 5 {
+				t.Errorf("Expected 30s with 5 workers, got %v with %d", config.Duration, config.Concurrency)
+			}
+			if config.TargetOrder != models.OrderSequential {
+				t.Errorf("Expected sequential order, got %q", config.TargetOrder)
+			}
+			if config.Headers.Get("X-Origem") != "carga" {
+				t.Errorf("Expected the scenario header, got %v", config.Headers)
+			}
+			if config.Checks == nil || len(config.Checks.Status) != 2 || config.Checks.MaxLatency != 250*time.Millisecond {
+				t.Errorf("Expected the scenario checks, got %+v", config.Checks)
+			}
+
+			if len(config.Targets) != 2 {
+				t.Fatalf("Expected 2 requests, got %d", len(config.Targets))
+			}
+			list, create := config.Targets[0], config.Targets[1]
+			if list.Name != "listar" || list.Weight != 3 || list.Checks != nil {
+				t.Errorf("Expected listar with weight 3 and the scenario checks, got %+v", list)
+			}
+			if create.Method != "POST" || string(create.Body) != `{"nome":"caneta"}` {
+				t.Errorf("Expected POST with a JSON body, got %s %s", create.Method, create.Body)
+			}
+			expected := []models.JSONCheck{{Path: "$.id", Exists: true}, {Path: "$.nome", Value: "caneta"}}
+			if create.Checks == nil || len(create.Checks.JSON) != 2 || create.Checks.JSON[0] != expected[0] || create.Checks.JSON[1] != expected[1] {
+				t.Errorf("Expected the request JSON checks %v, got %+v", expected, create.Checks)
+			}
+		})
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "usuarios.csv", "user\nana\n")
+	config, err := loadConfig(t, writeFile(t, dir, "cenario.yaml", `load:
+  requests: 10
+  concurrency: 2
+feeders:
+  - file: usuarios.csv
+requests:
+  - url: http://localhost:8080/saude
+`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if config.TargetOrder != models.OrderRandom {
+		t.Errorf("Expected random order by default, got %q", config.TargetOrder)
+	}
+	if config.Checks != nil {
+		t.Errorf("Expected no checks by default, got %+v", config.Checks)
+	}
+	if config.Duration != 0 || config.Stages != nil {
+		t.Errorf("Expected no duration or stages, got %v and %v", config.Duration, config.Stages)
+	}
+	target := config.Targets[0]
+	if target.Method != "" || target.Weight != 0 || target.Body != nil {
+		t.Errorf("Expected an empty method, weight and body, got %+v", target)
+	}
+	feeder := config.Feeders[0]
+	if feeder.Strategy != models.FeedSequential || feeder.OnExhausted != models.ExhaustedRecycle {
+		t.Errorf("Expected sequential and recycle feeder defaults, got %q and %q", feeder.Strategy, feeder.OnExhausted)
+	}
+}
+
+func TestConfigFlow(t *testing.T) {
+	config, err := loadConfig(t, writeFile(t, t.TempDir(), "fluxo.yaml", `load:
+  stages:
+    - duration: 1m
+      target: 10
+flow:
+  - name: login
+    method: POST
+    url: https://api.exemplo.com/login
+    extract:
+      - var: token
+        json: $.token
+      - var: sessao
+        header: X-Session
+  - name: perfil
+    url: https://api.exemplo.com/perfil?s={{sessao}}
+    headers:
+      Authorization: Bearer {{token}}
+`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(config.Stages) != 1 || config.Stages[0] != (models.Stage{Duration: time.Minute, Target: 10}) {
+		t.Errorf("Expected one 1m stage with 10 VUs, got %v", config.Stages)
+	}
+	if config.Targets != nil || len(config.Flow) != 2 {
+		t.Fatalf("Expected a 2-step flow and no requests, got %d steps and %d requests", len(config.Flow), len(config.Targets))
+	}
+	expected := []models.Extraction{
+		{Var: "token", Source: models.ExtractJSON, Expression: "$.token"},
+		{Var: "sessao", Source: models.ExtractHeader, Expression: "X-Session"},
+	}
+	login := config.Flow[0]
+	if len(login.Extract) != 2 || login.Extract[0] != expected[0] || login.Extract[1] != expected[1] {
+		t.Errorf("Expected extractions %v, got %v", expected, login.Extract)
+	}
+	if got := config.Flow[1].Request.Headers.Get("Authorization"); got != "Bearer {{token}}" {
+		t.Errorf("Expected the header template to be kept, got %q", got)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "campo desconhecido",
+			content: "load: {requests: 1, concurrency: 1}\nrequest:\n  - url: http://localhost\n",
+			err:     "field request not found",
+		},
+		{
+			name:    "sem requisições",
+			content: "load: {requests: 1, concurrency: 1}\n",
+			err:     "pelo menos uma requisição",
+		},
+		{
+			name:    "requisições repetidas",
+			content: "requests:\n  - {name: a, url: http://localhost}\n  - {name: a, url: http://localhost}\n",
+			err:     `requisição 2: nome "a" repetido`,
+		},
+		{
+			name:    "passos repetidos",
+			content: "flow:\n  - {name: a, url: http://localhost}\n  - {name: b, url: http://localhost}\n  - {name: a, url: http://localhost}\n",
+			err:     `passo 3: nome "a" repetido`,
+		},
+		{
+			name:    "requests e flow",
+			content: "requests:\n  - url: http://localhost\nflow:\n  - url: http://localhost\n",
+			err:     "não ambos",
+		},
+		{
+			name:    "URL inválida",
+			content: "requests:\n  - url: localhost/saude\n",
+			err:     "requisição 1: URL",
+		},
+		{
+			name:    "peso negativo",
+			content: "requests:\n  - {url: http://localhost, weight: -1}\n",
+			err:     "peso não pode ser negativo",
+		},
+		{
+			name:    "ordem inválida",
+			content: "order: weighted\nrequests:\n  - url: http://localhost\n",
+			err:     `ordem inválida "weighted"`,
+		},
+		{
+			name:    "duração inválida",
+			content: "load: {duration: 30}\nrequests:\n  - url: http://localhost\n",
+			err:     `duração inválida "30"`,
+		},
+		{
+			name:    "estágio inválido",
+			content: "load:\n  stages:\n    - {duration: 1m, target: 1}\n    - {duration: x, target: 0}\nrequests:\n  - url: http://localhost\n",
+			err:     "estágio 2",
+		},
+		{
+			name:    "latência inválida",
+			content: "checks: {max_latency: rápido}\nrequests:\n  - url: http://localhost\n",
+			err:     "latência máxima inválida",
+		},
+		{
+			name:    "verificação JSON sem caminho",
+			content: "requests:\n  - url: http://localhost\n    checks:\n      json:\n        - equals: x\n",
+			err:     "sem caminho",
+		},
+		{
+			name:    "extração com duas origens",
+			content: "flow:\n  - url: http://localhost\n    extract:\n      - {var: token, json: $.token, header: X-Token}\n",
+			err:     `extração "token" deve informar exatamente um`,
+		},
+		{
+			name:    "duas autenticações",
+			content: "auth: {bearer: abc, basic: {username: a, password: b}}\nrequests:\n  - url: http://localhost\n",
+			err:     "exatamente um de bearer, basic ou oauth2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig(t, writeFile(t, t.TempDir(), "cenario.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestConfigResolvesPathsRelativeToScenario(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "dados/usuarios.csv", "user\nana\nbeto\n")
+	absolute := filepath.Join(t.TempDir(), "ca.pem")
+
+	config, err := loadConfig(t, writeFile(t, dir, "cenario.yaml", `feeders:
+  - file: dados/usuarios.csv
+    strategy: unique
+    exhausted: stop
+client:
+  tls:
+    cert: certs/cliente.pem
+    key: certs/cliente-key.pem
+    cacert: `+absolute+`
+requests:
+  - url: https://api.exemplo.com/login
+`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	feeder := config.Feeders[0]
+	if feeder.Name != "usuarios.csv" || len(feeder.Records) != 2 || feeder.Records[1]["user"] != "beto" {
+		t.Errorf("Expected the feeder to be read next to the scenario, got %+v", feeder)
+	}
+	if feeder.Strategy != models.FeedUnique || feeder.OnExhausted != models.ExhaustedStop {
+		t.Errorf("Expected unique and stop, got %q and %q", feeder.Strategy, feeder.OnExhausted)
+	}
+
+	tls := config.Client.TLS
+	if tls.CertFile != filepath.Join(dir, "certs/cliente.pem") || tls.KeyFile != filepath.Join(dir, "certs/cliente-key.pem") {
+		t.Errorf("Expected the certificate paths relative to the scenario, got %q and %q", tls.CertFile, tls.KeyFile)
+	}
+	if tls.CAFile != absolute {
+		t.Errorf("Expected the absolute path %q to be kept, got %q", absolute, tls.CAFile)
+	}
+}
@@ -0,0 +1,320 @@
+package stresstest
+
+import (
+	"strings"
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+)
+
+// maxErrorCategories limita a quantidade de categorias de erro distintas
+// mantidas pelo agregador; as excedentes são agrupadas em uma categoria genérica
+const maxErrorCategories = 100
+
+// aggregator consolida os resultados de forma incremental, à medida que chegam
+// dos workers, mantendo apenas contadores e histogramas de memória fixa. Não é
+// seguro para uso concorrente: é alimentado por uma única goroutine coletora
+type aggregator struct {
+	config    models.TestConfig
+	summary   models.TestReport
+	latencies *metrics.Histogram
+	phases    phaseHistograms
+	stages    []*groupStats
+	endpoints []*groupStats
+	// journeys acumula as jornadas completas; nil sem fluxo configurado
+	journeys *groupStats
+	series   *timeSeries
+
+	// results guarda cada requisição apenas quando config.KeepResults é verdadeiro
+	results []models.RequestResult
+}
+
+// newAggregator cria um agregador para a configuração e o início do teste;
+// targetNames são os nomes das requisições selecionadas pelos workers
+func newAggregator(config models.TestConfig, startTime time.Time, targetNames []string) *aggregator {
+	a := &aggregator{
+		config: config,
+		summary: models.TestReport{
+			StatusCodes:     make(map[int]int),
+			ErrorCategories: make(map[string]int),
+			FailedChecks:    make(map[string]int),
+		},
+		latencies: metrics.NewHistogram(),
+		phases:    newPhaseHistograms(),
+		series:    newTimeSeries(startTime, config.MetricsInterval),
+	}
+
+	for _, name := range stageNames(config.Stages) {
+		a.stages = append(a.stages, newGroupStats(name))
+	}
+
+	if len(config.Flow) > 0 {
+		a.journeys = newGroupStats("Jornada completa")
+	}
+
+	// As estatísticas por requisição só fazem sentido com mais de uma
+	if len(targetNames) > 1 {
+		for _, name := range targetNames {
+			a.endpoints = append(a.endpoints, newGroupStats(name))
+		}
+	}
+
+	return a
+}
+
+// add incorpora o resultado de uma requisição às estatísticas
+func (a *aggregator) add(result models.RequestResult) {
+	a.summary.TotalRequests++
+
+	// Contabiliza códigos de status
+	a.summary.StatusCodes[result.StatusCode]++
+
+	// Contabiliza sucessos (2xx) e falhas
+	if isSuccess(result) {
+		a.summary.SuccessfulReqs++
+	} else {
+		a.summary.FailedReqs++
+	}
+
+	// Agrupa erros similares por categoria
+	if result.Error != nil {
+		category := categorizeError(result.Error.Error())
+		if _, exists := a.summary.ErrorCategories[category]; !exists && len(a.summary.ErrorCategories) >= maxErrorCategories {
+			category = "Outros Erros de Rede"
+		}
+		a.summary.ErrorCategories[category]++
+	}
+
+	// Reprovações nas verificações são contadas à parte dos erros de rede; com
+	// várias requisições, a descrição inclui o nome da requisição
+	if len(result.FailedChecks) > 0 {
+		a.summary.CheckFailures++
+		for _, check := range result.FailedChecks {
+			if result.Target < len(a.endpoints) {
+				check = a.endpoints[result.Target].summary.Name + ": " + check
+			}
+			a.summary.FailedChecks[check]++
+		}
+	}
+
+	// Registra o tempo de resposta no histograma de latências
+	a.latencies.Record(result.Duration)
+	a.phases.add(result)
+	a.series.add(result)
+	a.summary.TotalDataTransfer += result.ResponseSize
+
+	if result.Stage < len(a.stages) {
+		a.stages[result.Stage].add(result)
+	}
+
+	if result.Target < len(a.endpoints) {
+		a.endpoints[result.Target].add(result)
+	}
+
+	if result.Journey != nil && a.journeys != nil {
+		a.journeys.addJourney(*result.Journey)
+	}
+
+	if a.config.KeepResults {
+		a.results = append(a.results, result)
+	}
+}
+
+// observeInFlight registra uma amostra do número de requisições em andamento
+func (a *aggregator) observeInFlight(at time.Time, inFlight int) {
+	a.series.observeInFlight(at, inFlight)
+}
+
+// count retorna o número de iterações concluídas: jornadas, com um fluxo
+// configurado, ou requisições
+func (a *aggregator) count() int {
+	if a.journeys != nil {
+		return a.journeys.summary.TotalRequests
+	}
+	return a.summary.TotalRequests
+}
+
+// report gera o relatório consolidado para o tempo total de execução
+func (a *aggregator) report(totalTime time.Duration) models.TestReport {
+	report := a.summary
+	report.TotalTime = totalTime
+
+	// Calcula médias e outras métricas
+	if report.TotalRequests > 0 {
+		report.AvgResponseTime = a.latencies.Mean()
+		report.MinResponseTime = a.latencies.Min()
+		report.MaxResponseTime = a.latencies.Max()
+		report.StdDevResponseTime = a.latencies.StdDev()
+		report.Percentiles = a.latencies.Percentiles()
+		report.LatencyDistribution = latencyDistribution(a.latencies)
+		report.PercentileCurve = percentileCurve(a.latencies)
+		report.RequestsPerSec = float64(report.TotalRequests) / totalTime.Seconds()
+		report.Phases = a.phases.report()
+	}
+
+	report.TimeSeries = a.series.report(totalTime)
+
+	for i, stage := range a.stages {
+		report.Stages = append(report.Stages, stage.report(a.config.Stages[i].Duration))
+	}
+
+	for _, endpoint := range a.endpoints {
+		report.Endpoints = append(report.Endpoints, endpoint.report(totalTime))
+	}
+
+	if a.journeys != nil {
+		journeys := a.journeys.report(totalTime)
+		report.Journeys = &journeys
+	}
+
+	return report
+}
+
+// phaseHistograms acumula a distribuição de cada fase das requisições
+type phaseHistograms struct {
+	dns      *metrics.Histogram
+	connect  *metrics.Histogram
+	proxy    *metrics.Histogram
+	tls      *metrics.Histogram
+	ttfb     *metrics.Histogram
+	transfer *metrics.Histogram
+}
+
+// newPhaseHistograms cria os histogramas vazios de cada fase
+func newPhaseHistograms() phaseHistograms {
+	return phaseHistograms{
+		dns:      metrics.NewHistogram(),
+		connect:  metrics.NewHistogram(),
+		proxy:    metrics.NewHistogram(),
+		tls:      metrics.NewHistogram(),
+		ttfb:     metrics.NewHistogram(),
+		transfer: metrics.NewHistogram(),
+	}
+}
+
+// add registra as fases de uma requisição. DNS, conexão, proxy e TLS só entram na
+// distribuição quando ocorreram; espera e transferência, quando houve resposta
+func (p phaseHistograms) add(result models.RequestResult) {
+	timings := result.Timings
+	if timings.DNS > 0 {
+		p.dns.Record(timings.DNS)
+	}
+	if timings.Connect > 0 {
+		p.connect.Record(timings.Connect)
+	}
+	if timings.Proxy > 0 {
+		p.proxy.Record(timings.Proxy)
+	}
+	if timings.TLS > 0 {
+		p.tls.Record(timings.TLS)
+	}
+	if result.StatusCode != 0 {
+		p.ttfb.Record(timings.TTFB)
+		p.transfer.Record(timings.Transfer)
+	}
+}
+
+// report gera as estatísticas de cada fase
+func (p phaseHistograms) report() models.PhaseBreakdown {
+	return models.PhaseBreakdown{
+		DNS:      phaseStats(p.dns),
+		Connect:  phaseStats(p.connect),
+		Proxy:    phaseStats(p.proxy),
+		TLS:      phaseStats(p.tls),
+		TTFB:     phaseStats(p.ttfb),
+		Transfer: phaseStats(p.transfer),
+	}
+}
+
+// phaseStats extrai as estatísticas de uma fase a partir do seu histograma
+func phaseStats(h *metrics.Histogram) models.PhaseStats {
+	return models.PhaseStats{
+		Count:       h.Count(),
+		Avg:         h.Mean(),
+		Percentiles: h.Percentiles(),
+	}
+}
+
+// groupStats acumula as estatísticas de um subconjunto das requisições
+type groupStats struct {
+	summary   models.GroupReport
+	latencies *metrics.Histogram
+}
+
+// newGroupStats cria um acumulador vazio com o nome exibido no relatório
+func newGroupStats(name string) *groupStats {
+	return &groupStats{
+		summary:   models.GroupReport{Name: name},
+		latencies: metrics.NewHistogram(),
+	}
+}
+
+// add incorpora o resultado de uma requisição ao grupo
+func (g *groupStats) add(result models.RequestResult) {
+	g.summary.TotalRequests++
+	if isSuccess(result) {
+		g.summary.SuccessfulReqs++
+	} else {
+		g.summary.FailedReqs++
+	}
+	g.latencies.Record(result.Duration)
+}
+
+// addJourney incorpora uma jornada completa ao grupo
+func (g *groupStats) addJourney(journey models.JourneyResult) {
+	g.summary.TotalRequests++
+	if journey.Success {
+		g.summary.SuccessfulReqs++
+	} else {
+		g.summary.FailedReqs++
+	}
+	g.latencies.Record(journey.Duration)
+}
+
+// report gera as estatísticas do grupo; window é o intervalo usado no cálculo
+// da vazão (zero quando não se aplica)
+func (g *groupStats) report(window time.Duration) models.GroupReport {
+	report := g.summary
+	report.AvgResponseTime = g.latencies.Mean()
+	report.MaxResponseTime = g.latencies.Max()
+	report.Percentiles = g.latencies.Percentiles()
+	if window > 0 {
+		report.RequestsPerSec = float64(report.TotalRequests) / window.Seconds()
+	}
+	return report
+}
+
+// categorizeError agrupa erros similares por categoria
+func categorizeError(errorMsg string) string {
+	errorMsg = strings.ToLower(errorMsg)
+
+	switch {
+	case strings.Contains(errorMsg, "connection refused"):
+		return "Erros de Conexão Recusada"
+	case strings.Contains(errorMsg, "timeout"):
+		return "Erros de Timeout"
+	case strings.Contains(errorMsg, "no such host"):
+		return "Erros de DNS/Host não encontrado"
+	case strings.Contains(errorMsg, "tls handshake"):
+		return "Erros de Handshake TLS/SSL"
+	case strings.Contains(errorMsg, "goaway") && strings.Contains(errorMsg, "enhance_your_calm"):
+		return "Erros de Rate Limiting (ENHANCE_YOUR_CALM)"
+	case strings.Contains(errorMsg, "stopped after") && strings.Contains(errorMsg, "redirects"):
+		return "Erros de Muitos Redirecionamentos"
+	case strings.Contains(errorMsg, "context deadline exceeded"):
+		return "Erros de Deadline/Timeout de Contexto"
+	case strings.Contains(errorMsg, "eof"):
+		return "Erros de Fim de Arquivo (EOF)"
+	case strings.Contains(errorMsg, "connection reset"):
+		return "Erros de Reset de Conexão"
+	case strings.Contains(errorMsg, "network is unreachable"):
+		return "Erros de Rede Inacessível"
+	default:
+		// Para outros erros, retorna uma versão simplificada
+		if len(errorMsg) > 80 {
+			return "Outros Erros de Rede"
+		}
+		return strings.Title(errorMsg)
+	}
+}
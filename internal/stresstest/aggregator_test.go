@@ -0,0 +1,139 @@
+package stresstest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestAggregatorCapsErrorCategories(t *testing.T) {
+	a := newAggregator(models.TestConfig{}, time.Now(), nil)
+
+	// Mensagens curtas e distintas geram uma categoria cada
+	for i := 0; i < maxErrorCategories+10; i++ {
+		a.add(models.RequestResult{Error: fmt.Errorf("erro %d", i)})
+	}
+	// Uma categoria já conhecida continua sendo contada à parte
+	a.add(models.RequestResult{Error: errors.New("erro 0")})
+
+	report := a.report(time.Second)
+	if len(report.ErrorCategories) != maxErrorCategories+1 {
+		t.Fatalf("Expected %d error categories, got %d", maxErrorCategories+1, len(report.ErrorCategories))
+	}
+	if report.ErrorCategories["Outros Erros de Rede"] != 10 {
+		t.Errorf("Expected 10 errors grouped as other, got %d", report.ErrorCategories["Outros Erros de Rede"])
+	}
+	if report.ErrorCategories["Erro 0"] != 2 {
+		t.Errorf("Expected 2 errors in the first category, got %d", report.ErrorCategories["Erro 0"])
+	}
+	if report.FailedReqs != maxErrorCategories+11 {
+		t.Errorf("Expected %d failed requests, got %d", maxErrorCategories+11, report.FailedReqs)
+	}
+}
+
+func TestAggregatorGroupsByStageAndEndpoint(t *testing.T) {
+	config := models.TestConfig{Stages: []models.Stage{
+		{Duration: time.Second, Target: 2},
+		{Duration: 2 * time.Second, Target: 0},
+	}}
+	a := newAggregator(config, time.Now(), []string{"login", "perfil"})
+
+	a.add(models.RequestResult{StatusCode: 200, Duration: 10 * time.Millisecond, Stage: 0, Target: 0})
+	a.add(models.RequestResult{StatusCode: 500, Duration: 30 * time.Millisecond, Stage: 0, Target: 1})
+	a.add(models.RequestResult{StatusCode: 200, Duration: 20 * time.Millisecond, Stage: 1, Target: 1})
+
+	report := a.report(time.Second)
+
+	stages := []struct {
+		name             string
+		total, succeeded int
+		rps              float64
+	}{
+		{"Estágio 1: 0 → 2 VUs em 1s", 2, 1, 2},
+		{"Estágio 2: 2 → 0 VUs em 2s", 1, 1, 0.5},
+	}
+	if len(report.Stages) != len(stages) {
+		t.Fatalf("Expected %d stages, got %d", len(stages), len(report.Stages))
+	}
+	for i, want := range stages {
+		got := report.Stages[i]
+		if got.Name != want.name || got.TotalRequests != want.total || got.SuccessfulReqs != want.succeeded || got.RequestsPerSec != want.rps {
+			t.Errorf("Expected stage %d to be %+v, got %+v", i, want, got)
+		}
+	}
+
+	endpoints := []struct {
+		name             string
+		total, succeeded int
+		max              time.Duration
+	}{
+		{"login", 1, 1, 10 * time.Millisecond},
+		{"perfil", 2, 1, 30 * time.Millisecond},
+	}
+	if len(report.Endpoints) != len(endpoints) {
+		t.Fatalf("Expected %d endpoints, got %d", len(endpoints), len(report.Endpoints))
+	}
+	for i, want := range endpoints {
+		got := report.Endpoints[i]
+		if got.Name != want.name || got.TotalRequests != want.total || got.SuccessfulReqs != want.succeeded || got.MaxResponseTime != want.max {
+			t.Errorf("Expected endpoint %d to be %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestAggregatorSkipsEndpointsForSingleRequest(t *testing.T) {
+	a := newAggregator(models.TestConfig{}, time.Now(), []string{"único"})
+	a.add(models.RequestResult{StatusCode: 200})
+
+	if report := a.report(time.Second); report.Endpoints != nil {
+		t.Errorf("Expected no endpoint breakdown for a single request, got %d", len(report.Endpoints))
+	}
+}
+
+func TestAggregatorNamesFailedChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		want    map[string]int
+	}{
+		{
+			name:    "single request",
+			targets: []string{"único"},
+			want:    map[string]int{"status 500": 2, "corpo": 1},
+		},
+		{
+			name:    "several requests",
+			targets: []string{"login", "perfil"},
+			want:    map[string]int{"login: status 500": 1, "perfil: status 500": 1, "perfil: corpo": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAggregator(models.TestConfig{}, time.Now(), tt.targets)
+			a.add(models.RequestResult{StatusCode: 500, FailedChecks: []string{"status 500"}})
+			a.add(models.RequestResult{StatusCode: 500, FailedChecks: []string{"status 500", "corpo"}, Target: len(tt.targets) - 1})
+			a.add(models.RequestResult{StatusCode: 200})
+
+			report := a.report(time.Second)
+			// CheckFailures conta requisições, não verificações
+			if report.CheckFailures != 2 {
+				t.Errorf("Expected 2 requests with failed checks, got %d", report.CheckFailures)
+			}
+			if len(report.FailedChecks) != len(tt.want) {
+				t.Errorf("Expected failed checks %v, got %v", tt.want, report.FailedChecks)
+			}
+			for check, count := range tt.want {
+				if report.FailedChecks[check] != count {
+					t.Errorf("Expected %d failures of %q, got %d", count, check, report.FailedChecks[check])
+				}
+			}
+			if len(report.ErrorCategories) != 0 {
+				t.Errorf("Expected no network errors, got %v", report.ErrorCategories)
+			}
+		})
+	}
+}
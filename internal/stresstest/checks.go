@@ -0,0 +1,176 @@
+package stresstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"stresstest/internal/models"
+)
+
+// checker aplica as verificações de uma requisição às respostas, com as
+// expressões regulares e os caminhos JSON já compilados
+type checker struct {
+	checks   models.Checks
+	status   map[int]bool
+	patterns []*regexp.Regexp
+	paths    [][]pathSegment
+}
+
+// newChecker valida e compila as verificações; retorna nil sem verificações
+func newChecker(checks *models.Checks) (*checker, error) {
+	if checks == nil {
+		return nil, nil
+	}
+
+	c := &checker{checks: *checks}
+
+	if len(checks.Status) > 0 {
+		c.status = make(map[int]bool, len(checks.Status))
+		for _, code := range checks.Status {
+			if code < 100 || code > 999 {
+				return nil, fmt.Errorf("código de status esperado inválido: %d", code)
+			}
+			c.status[code] = true
+		}
+	}
+
+	for _, expression := range checks.BodyRegex {
+		pattern, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("verificação do corpo inválida: %w", err)
+		}
+		c.patterns = append(c.patterns, pattern)
+	}
+
+	for _, check := range checks.JSON {
+		path, err := parseJSONPath(check.Path)
+		if err != nil {
+			return nil, fmt.Errorf("verificação JSON inválida: %w", err)
+		}
+		c.paths = append(c.paths, path)
+	}
+
+	if checks.MaxLatency < 0 || checks.MinSize < 0 || checks.MaxSize < 0 {
+		return nil, fmt.Errorf("limites das verificações não podem ser negativos")
+	}
+	if checks.MaxSize > 0 && checks.MinSize > checks.MaxSize {
+		return nil, fmt.Errorf("o tamanho mínimo do corpo é maior que o máximo")
+	}
+
+	return c, nil
+}
+
+// evaluate registra no resultado as verificações em que a resposta foi
+// reprovada. Requisições sem resposta ou com erro de transporte não são
+// verificadas
+func (c *checker) evaluate(result *models.RequestResult, resp *response) {
+	if c == nil || resp == nil || result.Error != nil {
+		return
+	}
+
+	checks := c.checks
+	fail := func(description string) {
+		result.FailedChecks = append(result.FailedChecks, description)
+	}
+
+	if c.status != nil {
+		result.StatusChecked = true
+		if !c.status[result.StatusCode] {
+			fail(describeStatus(checks.Status))
+		}
+	}
+
+	for _, text := range checks.BodyContains {
+		if !bytes.Contains(resp.body, []byte(text)) {
+			fail(fmt.Sprintf("corpo contém %q", text))
+		}
+	}
+
+	for _, pattern := range c.patterns {
+		if !pattern.Match(resp.body) {
+			fail(fmt.Sprintf("corpo corresponde a /%s/", pattern))
+		}
+	}
+
+	if len(c.paths) > 0 {
+		// O corpo é decodificado uma única vez para todas as verificações JSON
+		var document interface{}
+		decoder := json.NewDecoder(bytes.NewReader(resp.body))
+		decoder.UseNumber()
+		valid := decoder.Decode(&document) == nil
+
+		for i, check := range checks.JSON {
+			value, err := lookupJSON(document, c.paths[i])
+			if !valid || err != nil || !check.Exists && value != check.Value {
+				fail(describeJSONCheck(check))
+			}
+		}
+	}
+
+	for _, name := range checks.Headers {
+		if resp.header.Get(name) == "" {
+			fail(fmt.Sprintf("cabeçalho %s presente", name))
+		}
+	}
+
+	if checks.MaxLatency > 0 && result.Duration > checks.MaxLatency {
+		fail(fmt.Sprintf("latência ≤ %v", checks.MaxLatency))
+	}
+	if checks.MinSize > 0 && result.ResponseSize < checks.MinSize {
+		fail(fmt.Sprintf("corpo ≥ %d bytes", checks.MinSize))
+	}
+	if checks.MaxSize > 0 && result.ResponseSize > checks.MaxSize {
+		fail(fmt.Sprintf("corpo ≤ %d bytes", checks.MaxSize))
+	}
+}
+
+// describeChecks lista as verificações configuradas para exibição
+func describeChecks(checks models.Checks) string {
+	var parts []string
+	if len(checks.Status) > 0 {
+		parts = append(parts, describeStatus(checks.Status))
+	}
+	for _, text := range checks.BodyContains {
+		parts = append(parts, fmt.Sprintf("corpo contém %q", text))
+	}
+	for _, expression := range checks.BodyRegex {
+		parts = append(parts, fmt.Sprintf("corpo corresponde a /%s/", expression))
+	}
+	for _, check := range checks.JSON {
+		parts = append(parts, describeJSONCheck(check))
+	}
+	for _, name := range checks.Headers {
+		parts = append(parts, fmt.Sprintf("cabeçalho %s presente", name))
+	}
+	if checks.MaxLatency > 0 {
+		parts = append(parts, fmt.Sprintf("latência ≤ %v", checks.MaxLatency))
+	}
+	if checks.MinSize > 0 {
+		parts = append(parts, fmt.Sprintf("corpo ≥ %d bytes", checks.MinSize))
+	}
+	if checks.MaxSize > 0 {
+		parts = append(parts, fmt.Sprintf("corpo ≤ %d bytes", checks.MaxSize))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// describeStatus descreve a verificação dos códigos de status aceitos
+func describeStatus(codes []int) string {
+	texts := make([]string, len(codes))
+	for i, code := range codes {
+		texts[i] = strconv.Itoa(code)
+	}
+	return "status " + strings.Join(texts, ", ")
+}
+
+// describeJSONCheck descreve uma verificação de campo JSON
+func describeJSONCheck(check models.JSONCheck) string {
+	if check.Exists {
+		return fmt.Sprintf("%s presente", check.Path)
+	}
+	return fmt.Sprintf("%s = %q", check.Path, check.Value)
+}
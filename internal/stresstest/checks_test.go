@@ -0,0 +1,81 @@
+package stresstest
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestCheckerEvaluate(t *testing.T) {
+	c, err := newChecker(&models.Checks{
+		Status:       []int{200, 404},
+		BodyContains: []string{`"status"`},
+		BodyRegex:    []string{`"id":\d+`},
+		JSON: []models.JSONCheck{
+			{Path: "$.status", Value: "ok"},
+			{Path: "$.data.id", Exists: true},
+		},
+		Headers:    []string{"X-Request-Id"},
+		MaxLatency: 100 * time.Millisecond,
+		MaxSize:    1024,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp := &response{
+		header: http.Header{"X-Request-Id": []string{"r-1"}},
+		body:   []byte(`{"status":"ok","data":{"id":7}}`),
+	}
+
+	passed := models.RequestResult{StatusCode: 404, Duration: 10 * time.Millisecond, ResponseSize: int64(len(resp.body))}
+	c.evaluate(&passed, resp)
+	if len(passed.FailedChecks) != 0 || !isSuccess(passed) {
+		t.Errorf("Expected an accepted 404 to succeed, got %v", passed.FailedChecks)
+	}
+
+	// Um 200 com payload de erro é reprovado nas verificações
+	errorPayload := &response{header: http.Header{}, body: []byte(`{"status":"error"}`)}
+	failed := models.RequestResult{StatusCode: 200, Duration: time.Second, ResponseSize: 18}
+	c.evaluate(&failed, errorPayload)
+
+	expected := []string{
+		`corpo corresponde a /"id":\d+/`,
+		`$.status = "ok"`,
+		"$.data.id presente",
+		"cabeçalho X-Request-Id presente",
+		"latência ≤ 100ms",
+	}
+	if !reflect.DeepEqual(failed.FailedChecks, expected) {
+		t.Errorf("Expected %v, got %v", expected, failed.FailedChecks)
+	}
+	if isSuccess(failed) {
+		t.Error("Expected a response with failed checks to be unsuccessful")
+	}
+
+	// Erros de transporte não passam pelas verificações
+	transport := models.RequestResult{Error: errors.New("connection refused")}
+	c.evaluate(&transport, nil)
+	if len(transport.FailedChecks) != 0 {
+		t.Errorf("Expected no checks without a response, got %v", transport.FailedChecks)
+	}
+}
+
+func TestNewCheckerValidation(t *testing.T) {
+	invalid := []models.Checks{
+		{Status: []int{42}},
+		{BodyRegex: []string{"("}},
+		{JSON: []models.JSONCheck{{Path: "$."}}},
+		{MinSize: 10, MaxSize: 5},
+	}
+
+	for _, checks := range invalid {
+		if _, err := newChecker(&checks); err == nil {
+			t.Errorf("Expected an error for %+v", checks)
+		}
+	}
+}
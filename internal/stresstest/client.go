@@ -0,0 +1,92 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+
+	"stresstest/internal/httpclient"
+	"stresstest/internal/models"
+)
+
+// runClient retorna o cliente HTTP compartilhado de uma execução: o do
+// executor ou, quando as opções exigem, uma cópia com transporte próprio
+func (e *Executor) runClient(options models.ClientOptions) (*http.Client, error) {
+	if !httpclient.Custom(options) {
+		return e.client, nil
+	}
+
+	transport, err := httpclient.NewTransport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	traceProxy(transport)
+
+	client := *e.client
+	client.Transport = transport
+	return &client, nil
+}
+
+// traceProxy marca as requisições encaminhadas pelo proxy do transporte, seja
+// o informado nas opções ou o das variáveis HTTP_PROXY e HTTPS_PROXY, para
+// medir o tempo de túnel
+func traceProxy(transport *http.Transport) {
+	proxy := transport.Proxy
+	if proxy == nil {
+		return
+	}
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := proxy(req)
+		if phases, ok := req.Context().Value(recorderKey{}).(*phaseRecorder); ok && proxyURL != nil {
+			phases.markProxied()
+		}
+		return proxyURL, err
+	}
+}
+
+// userClient retorna o cliente HTTP de um usuário virtual: o cliente
+// compartilhado da execução ou, com cookies ou conexões isolados, uma cópia
+// com estado próprio. O transporte retornado é nil quando é compartilhado
+func userClient(base *http.Client, options models.ClientOptions) (*http.Client, *http.Transport) {
+	if !options.CookieJar && !options.IsolatedConnections {
+		return base, nil
+	}
+
+	client := *base
+
+	var transport *http.Transport
+	if options.IsolatedConnections {
+		transport = cloneTransport(base.Transport)
+		client.Transport = transport
+	}
+
+	if options.CookieJar {
+		// cookiejar.New só retorna erro com opções inválidas
+		client.Jar, _ = cookiejar.New(nil)
+	}
+
+	return &client, transport
+}
+
+// cloneTransport cria um transporte com a mesma configuração do informado,
+// mas sem conexões em comum
+func cloneTransport(base http.RoundTripper) *http.Transport {
+	if transport, ok := base.(*http.Transport); ok {
+		return httpclient.Clone(transport)
+	}
+	return httpclient.Default()
+}
+
+// describeClient descreve o estado mantido por cada usuário virtual
+func describeClient(options models.ClientOptions) string {
+	var parts []string
+	if options.CookieJar {
+		parts = append(parts, "cookies próprios")
+	}
+	if options.IsolatedConnections {
+		parts = append(parts, "conexões isoladas")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,112 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+// get envia uma requisição GET e descarta a resposta
+func get(t *testing.T, client *http.Client, url string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestUserClientKeepsCookiesPerWorker(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user := r.URL.Query().Get("login"); user != "" {
+			http.SetCookie(w, &http.Cookie{Name: "sessao", Value: user})
+			return
+		}
+		session := ""
+		if cookie, err := r.Cookie("sessao"); err == nil {
+			session = cookie.Value
+		}
+		mu.Lock()
+		received = append(received, session)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	base := NewExecutor().client
+	options := models.ClientOptions{CookieJar: true}
+	ana, _ := userClient(base, options)
+	beto, _ := userClient(base, options)
+
+	get(t, ana, server.URL+"/?login=ana")
+	get(t, ana, server.URL)
+	get(t, beto, server.URL)
+	get(t, beto, server.URL+"/?login=beto")
+	get(t, beto, server.URL)
+	get(t, ana, server.URL)
+
+	// Cada worker envia apenas os cookies que recebeu
+	expected := []string{"ana", "", "beto", "ana"}
+	for i, session := range expected {
+		if received[i] != session {
+			t.Errorf("Expected request %d to carry session %q, got %q", i+1, session, received[i])
+		}
+	}
+	if base.Jar != nil {
+		t.Error("Expected the shared client to stay without cookies")
+	}
+}
+
+func TestUserClientIsolatedConnections(t *testing.T) {
+	var mu sync.Mutex
+	connections := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections[r.RemoteAddr] = true
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		options     models.ClientOptions
+		connections int
+	}{
+		{name: "shared", connections: 1},
+		{name: "isolated", options: models.ClientOptions{IsolatedConnections: true}, connections: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(connections)
+			base := NewExecutor().client
+			defer base.CloseIdleConnections()
+
+			first, firstTransport := userClient(base, tt.options)
+			second, secondTransport := userClient(base, tt.options)
+			for i := 0; i < 3; i++ {
+				get(t, first, server.URL)
+				get(t, second, server.URL)
+			}
+
+			if tt.options.IsolatedConnections {
+				if firstTransport == nil || secondTransport == nil || firstTransport == secondTransport || first.Transport == base.Transport {
+					t.Fatal("Expected each worker to have its own transport")
+				}
+				defer firstTransport.CloseIdleConnections()
+				defer secondTransport.CloseIdleConnections()
+			} else if firstTransport != nil || first != base {
+				t.Error("Expected the workers to use the shared client")
+			}
+
+			// As conexões mantidas são reaproveitadas apenas dentro do transporte
+			if len(connections) != tt.connections {
+				t.Errorf("Expected %d connections, got %d", tt.connections, len(connections))
+			}
+		})
+	}
+}
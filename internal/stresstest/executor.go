@@ -1,87 +1,263 @@
 package stresstest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"stresstest/internal/auth"
+	"stresstest/internal/httpclient"
+	"stresstest/internal/metrics"
 	"stresstest/internal/models"
 )
 
+// resultsBuffer é a capacidade do canal de resultados entre os workers e o agregador
+const resultsBuffer = 1024
+
+// lateThreshold é o atraso de despacho a partir do qual uma iteração do modo
+// de taxa constante é considerada atrasada
+const lateThreshold = 10 * time.Millisecond
+
 // Executor gerencia a execução do teste de carga
 type Executor struct {
 	client *http.Client
+	out    io.Writer
+	// inFlight conta as requisições em andamento na execução atual
+	inFlight atomic.Int64
+	// counter é o contador compartilhado pelos templates ({{counter}})
+	counter atomic.Uint64
+}
+
+// job representa uma iteração a ser executada por um worker
+type job struct {
+	id int
+	// scheduled é o instante planejado de envio no modo de taxa constante;
+	// zero no modo fechado
+	scheduled time.Time
+	// stage é o índice do estágio do perfil de carga no momento em que um
+	// worker recebe a iteração
+	stage int
+}
+
+// response contém a parte da resposta usada na extração de valores do fluxo
+type response struct {
+	header http.Header
+	body   []byte
+}
+
+// dispatchStats contém os contadores do despacho de iterações
+type dispatchStats struct {
+	dropped int
+	late    int
 }
 
 // NewExecutor cria uma nova instância do executor
 func NewExecutor() *Executor {
+	transport := httpclient.Default()
+	traceProxy(transport)
+
 	return &Executor{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
+		out: os.Stdout,
 	}
 }
 
+// SetOutput define onde o executor escreve o cabeçalho e o progresso do teste
+func (e *Executor) SetOutput(w io.Writer) {
+	e.out = w
+}
+
 // Run executa o teste de carga com a configuração especificada
 func (e *Executor) Run(ctx context.Context, config models.TestConfig) (*models.StressTestResult, error) {
-	fmt.Printf("Iniciando teste de carga...\n")
-	fmt.Printf("URL: %s\n", config.URL)
-	fmt.Printf("Requisições: %d\n", config.Requests)
-	fmt.Printf("Concorrência: %d\n", config.Concurrency)
-	fmt.Println(strings.Repeat("=", 50))
+	// Com um perfil em estágios a duração do teste é a soma dos estágios
+	if len(config.Stages) > 0 {
+		config.Duration = stagesDuration(config.Stages)
+	}
+
+	// Prepara as requisições de cada iteração
+	work, err := newWorkload(config)
+	if err != nil {
+		return nil, err
+	}
+
+	work.httpClient, err = e.runClient(config.Client)
+	if err != nil {
+		return nil, fmt.Errorf("configuração do cliente inválida: %w", err)
+	}
+	defer work.httpClient.CloseIdleConnections()
+
+	work.auth, err = auth.New(config.Auth, work.httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("autenticação inválida: %w", err)
+	}
+
+	fmt.Fprintf(e.out, "Iniciando teste de carga...\n")
+	if len(config.Flow) > 0 {
+		fmt.Fprintf(e.out, "Fluxo: %d passos (%s)\n", len(config.Flow), strings.Join(work.names(), " → "))
+	} else if len(config.Targets) > 0 {
+		order := config.TargetOrder
+		if order == "" {
+			order = models.OrderSequential
+		}
+		fmt.Fprintf(e.out, "Requisições configuradas: %d (ordem %s)\n", len(config.Targets), order)
+	} else {
+		fmt.Fprintf(e.out, "URL: %s\n", config.URL)
+		if config.Method != "" && config.Method != http.MethodGet {
+			fmt.Fprintf(e.out, "Método: %s\n", config.Method)
+		}
+	}
+	if config.Requests > 0 {
+		fmt.Fprintf(e.out, "Requisições: %d\n", config.Requests)
+	}
+	if config.Client.CookieJar || config.Client.IsolatedConnections {
+		fmt.Fprintf(e.out, "Usuários virtuais: %s\n", describeClient(config.Client))
+	}
+	for _, feeder := range config.Feeders {
+		fmt.Fprintf(e.out, "Dados: %s (%d registros, estratégia %s, ao esgotar: %s)\n",
+			feeder.Name, len(feeder.Records), feeder.Strategy, feeder.OnExhausted)
+	}
+	if config.Duration > 0 {
+		fmt.Fprintf(e.out, "Duração: %v\n", config.Duration)
+	}
+	switch {
+	case config.Rate > 0:
+		fmt.Fprintf(e.out, "Taxa: %.2f req/s (modo aberto)\n", config.Rate)
+		fmt.Fprintf(e.out, "Limite de requisições em andamento: %d\n", config.Concurrency)
+	case len(config.Stages) > 0:
+		fmt.Fprintf(e.out, "Estágios: %s\n", describeStages(config.Stages))
+	default:
+		fmt.Fprintf(e.out, "Concorrência: %d\n", config.Concurrency)
+	}
+	if tls := httpclient.Describe(config.Client.TLS); tls != "" {
+		fmt.Fprintf(e.out, "TLS: %s\n", tls)
+	}
+	if config.Client.Proxy != "" {
+		fmt.Fprintf(e.out, "Proxy: %s\n", httpclient.DescribeProxy(config.Client.Proxy))
+	}
+	if dns := httpclient.DescribeDNS(config.Client.DNS); dns != "" {
+		fmt.Fprintf(e.out, "DNS: %s\n", dns)
+	}
+	if config.Client.H2C {
+		fmt.Fprintf(e.out, "Protocolo: HTTP/2 sem TLS (h2c)\n")
+	}
+	if config.Auth.Type != "" {
+		fmt.Fprintf(e.out, "Autenticação: %s\n", auth.Describe(config.Auth))
+	}
+	if config.Checks != nil {
+		fmt.Fprintf(e.out, "Verificações: %s\n", describeChecks(*config.Checks))
+	}
+	fmt.Fprintln(e.out, strings.Repeat("=", 50))
+
+	// Obtém as credenciais antes do início do teste; a renovação em segundo
+	// plano termina junto com a execução
+	authCtx, stopAuth := context.WithCancel(ctx)
+	defer stopAuth()
+	if work.auth != nil {
+		if err := work.auth.Start(authCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	// O arquivo de resultados é criado antes do teste para que um caminho
+	// inválido não desperdice a execução
+	var sink *resultSink
+	if config.ResultsFile != "" {
+		sink, err = newResultSink(config.ResultsFile, resultsFormat(config.ResultsFile, config.ResultsFormat), work.names())
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar o arquivo de resultados: %w", err)
+		}
+	}
 
 	startTime := time.Now()
+	e.inFlight.Store(0)
+	e.counter.Store(0)
 
-	// Canal para enviar trabalhos (requisições a serem feitas)
-	jobs := make(chan int, config.Requests)
+	// O canal de trabalhos não tem buffer: cada iteração só é criada quando há
+	// um worker livre, então nenhuma requisição fica enfileirada além do prazo
+	// e, no modo de taxa constante, o envio sem worker livre é descartado
+	jobs := make(chan job)
 
-	// Canal para receber resultados
-	results := make(chan models.RequestResult, config.Requests)
+	// Canal para receber resultados, consumido continuamente pelo agregador
+	results := make(chan models.RequestResult, resultsBuffer)
 
 	// WaitGroup para esperar todos os workers terminarem
 	var wg sync.WaitGroup
 
-	// Inicia os workers
-	for i := 0; i < config.Concurrency; i++ {
+	// Sinaliza o fim do despacho de iterações
+	dispatchDone := make(chan struct{})
+
+	// O despacho pode ser encerrado antes do fim do teste quando uma fonte de
+	// dados se esgota, sem interromper as requisições em andamento
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	defer stopDispatch()
+	work.stopDispatch = stopDispatch
+
+	// Inicia os workers; com um perfil em estágios o controlador adiciona e
+	// remove workers durante o teste
+	if len(config.Stages) > 0 {
+		work.currentStage = func() int {
+			_, stage := stageAt(config.Stages, time.Since(startTime))
+			return stage
+		}
 		wg.Add(1)
-		go e.worker(ctx, config.URL, jobs, results, &wg)
+		go e.controlStages(ctx, config, startTime, work, jobs, results, dispatchDone, &wg)
+	} else {
+		for i := 0; i < config.Concurrency; i++ {
+			wg.Add(1)
+			go e.worker(ctx, i+1, work, jobs, results, nil, &wg)
+		}
 	}
 
-	// Envia todas as requisições para o canal de trabalhos
+	// Envia as iterações para os workers em segundo plano
+	var stats dispatchStats
 	go func() {
+		defer close(dispatchDone)
 		defer close(jobs)
-		for i := 0; i < config.Requests; i++ {
-			select {
-			case jobs <- i:
-			case <-ctx.Done():
-				return
-			}
+		if config.Rate > 0 {
+			stats = e.dispatchRate(dispatchCtx, config, startTime, jobs)
+		} else {
+			e.dispatchClosed(dispatchCtx, config, jobs)
 		}
 	}()
 
-	// Coleta todos os resultados
-	var allResults []models.RequestResult
+	// Consolida os resultados à medida que chegam, sem manter cada requisição
+	// em memória (exceto quando KeepResults é solicitado)
+	agg := newAggregator(config, startTime, work.names())
 	var resultWg sync.WaitGroup
 	resultWg.Add(1)
 
 	go func() {
 		defer resultWg.Done()
-		for i := 0; i < config.Requests; i++ {
-			select {
-			case result := <-results:
-				allResults = append(allResults, result)
+		progress := newProgressTracker(e.out, config, startTime)
+
+		// Amostra periodicamente as requisições em andamento para a série temporal
+		ticker := time.NewTicker(inFlightSampleInterval)
+		defer ticker.Stop()
 
-				// Mostra progresso a cada 10% das requisições
-				if (i+1)%(config.Requests/10) == 0 || i+1 == config.Requests {
-					progress := float64(i+1) / float64(config.Requests) * 100
-					fmt.Printf("Progresso: %.1f%% (%d/%d requisições)\n", progress, i+1, config.Requests)
+		for {
+			select {
+			case result, ok := <-results:
+				if !ok {
+					return
 				}
-			case <-ctx.Done():
-				return
+				agg.add(result)
+				if sink != nil {
+					sink.write(result)
+				}
+				progress.update(agg.count())
+			case now := <-ticker.C:
+				agg.observeInFlight(now, int(e.inFlight.Load()))
 			}
 		}
 	}()
@@ -93,129 +269,325 @@ func (e *Executor) Run(ctx context.Context, config models.TestConfig) (*models.S
 	// Espera todos os resultados serem coletados
 	resultWg.Wait()
 
+	// O tempo total inclui as requisições que estavam em andamento no fim do
+	// prazo, para que a taxa reflita a janela real de execução
 	totalTime := time.Since(startTime)
 
+	// A falha na gravação dos resultados não descarta o teste: é devolvida
+	// junto com o relatório
+	var sinkErr error
+	if sink != nil {
+		sinkErr = sink.close()
+	}
+
 	// Gera o relatório
-	report := e.generateReport(allResults, totalTime, config.Requests)
+	report := agg.report(totalTime)
+	report.DroppedIterations = stats.dropped
+	report.LateIterations = stats.late
+	report.FeederExhausted = work.exhausted.Load()
+	if work.auth != nil {
+		report.TokenRequests = work.auth.Report()
+	}
 
 	return &models.StressTestResult{
 		Config:  config,
 		Report:  report,
-		Results: allResults,
+		Results: agg.results,
+		// Erros na gravação dos resultados são informados sem descartar o teste
+		ResultsFileError: sinkErr,
 	}, nil
 }
 
-// worker executa requisições HTTP de forma concorrente
-func (e *Executor) worker(ctx context.Context, url string, jobs <-chan int, results chan<- models.RequestResult, wg *sync.WaitGroup) {
+// dispatchClosed envia as iterações do modo fechado até atingir o total
+// configurado ou o prazo do teste, o que ocorrer primeiro. Cada envio aguarda um
+// worker livre, então a carga oferecida acompanha o tempo de resposta
+func (e *Executor) dispatchClosed(ctx context.Context, config models.TestConfig, jobs chan<- job) {
+	// Prazo para emissão de novas requisições (nil quando o teste não é por tempo)
+	var deadline <-chan time.Time
+	if config.Duration > 0 {
+		timer := time.NewTimer(config.Duration)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for i := 0; config.Requests == 0 || i < config.Requests; i++ {
+		select {
+		case jobs <- job{id: i}:
+		case <-deadline:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchRate agenda as iterações em uma linha do tempo fixa de acordo com a
+// taxa configurada. Se não houver worker livre no instante planejado a iteração
+// é descartada, em vez de atrasar as seguintes
+func (e *Executor) dispatchRate(ctx context.Context, config models.TestConfig, startTime time.Time, jobs chan<- job) dispatchStats {
+	var stats dispatchStats
+	interval := float64(time.Second) / config.Rate
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for i := 0; config.Requests == 0 || i < config.Requests; i++ {
+		offset := time.Duration(float64(i) * interval)
+		if config.Duration > 0 && offset >= config.Duration {
+			return stats
+		}
+		scheduled := startTime.Add(offset)
+
+		// Aguarda o instante planejado; iterações já vencidas são despachadas
+		// imediatamente para recuperar o atraso
+		if wait := time.Until(scheduled); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return stats
+			}
+		} else if ctx.Err() != nil {
+			return stats
+		}
+
+		if time.Since(scheduled) > lateThreshold {
+			stats.late++
+		}
+
+		select {
+		case jobs <- job{id: i, scheduled: scheduled}:
+		default:
+			stats.dropped++
+		}
+	}
+
+	return stats
+}
+
+// progressTracker exibe o progresso do teste a cada 10%, medido pelo número de
+// requisições ou pelo tempo decorrido em testes por duração
+type progressTracker struct {
+	out       io.Writer
+	config    models.TestConfig
+	startTime time.Time
+	step      int
+	nextStep  int
+}
+
+// newProgressTracker cria um acompanhador de progresso para a configuração
+func newProgressTracker(out io.Writer, config models.TestConfig, startTime time.Time) *progressTracker {
+	step := config.Requests / 10
+	if step == 0 {
+		step = 1
+	}
+	return &progressTracker{
+		out:       out,
+		config:    config,
+		startTime: startTime,
+		step:      step,
+		nextStep:  1,
+	}
+}
+
+// update registra uma nova requisição concluída e exibe o progresso quando necessário
+func (p *progressTracker) update(completed int) {
+	if p.config.Duration > 0 {
+		elapsed := time.Since(p.startTime)
+		if p.nextStep > 10 || elapsed < p.config.Duration*time.Duration(p.nextStep)/10 {
+			return
+		}
+		for p.nextStep <= 10 && elapsed >= p.config.Duration*time.Duration(p.nextStep)/10 {
+			p.nextStep++
+		}
+		fmt.Fprintf(p.out, "Progresso: %.1f%% (%v/%v, %d requisições)\n",
+			float64(p.nextStep-1)*10, elapsed.Round(time.Second), p.config.Duration, completed)
+		return
+	}
+
+	if completed%p.step == 0 || completed == p.config.Requests {
+		progress := float64(completed) / float64(p.config.Requests) * 100
+		fmt.Fprintf(p.out, "Progresso: %.1f%% (%d/%d requisições)\n", progress, completed, p.config.Requests)
+	}
+}
+
+// worker executa as iterações recebidas até o fim do despacho. Cada worker é
+// um usuário virtual, identificado nos templates por id ({{workerID}}), que
+// envia uma requisição ou, com um fluxo configurado, a jornada completa a cada
+// iteração. stop encerra o worker antes do fim do teste e é nil quando o número
+// de workers é fixo
+func (e *Executor) worker(ctx context.Context, id int, work *workload, jobs <-chan job, results chan<- models.RequestResult, stop <-chan struct{}, wg *sync.WaitGroup) {
 	defer wg.Done()
 
+	client, transport := userClient(work.httpClient, work.client)
+	user := newVirtualUser(id, &e.counter, client, transport)
+	user.auth = work.auth
+	defer user.close()
+
 	for {
+		// Um worker encerrado não recebe novas iterações, mesmo com outras
+		// prontas no canal: o select escolheria entre os dois ao acaso
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
 		select {
-		case _, ok := <-jobs:
+		case j, ok := <-jobs:
 			if !ok {
 				return // Canal fechado
 			}
-			result := e.makeRequest(ctx, url)
+			// O estágio é o da entrega da iteração, e não o do início da
+			// espera pelo worker, que pode ter ocorrido no estágio anterior
+			if work.currentStage != nil {
+				j.stage = work.currentStage()
+			}
+			if !work.feed(user) {
+				return // Dados esgotados
+			}
+			if work.flow != nil {
+				e.runJourney(ctx, work.flow, user, j, results)
+				continue
+			}
+
+			target, _ := work.targets.pick()
+			sent := time.Now()
+
+			var result models.RequestResult
+			var resp *response
+			request := work.requests[target]
+			spec, err := request.render(&user.template)
+			if err != nil {
+				result = models.RequestResult{Timestamp: sent, Error: err}
+			} else {
+				e.inFlight.Add(1)
+				result, resp = e.makeRequest(ctx, user, spec)
+				e.inFlight.Add(-1)
+			}
+
+			// No modo de taxa constante a latência é medida a partir do instante
+			// planejado, evitando a omissão coordenada. As verificações avaliam
+			// a mesma latência do relatório
+			if !j.scheduled.IsZero() {
+				result.Duration += sent.Sub(j.scheduled)
+				result.Timestamp = j.scheduled
+			}
+			request.checks.evaluate(&result, resp)
+			result.Worker = user.template.WorkerID
+			result.Stage = j.stage
+			result.Target = target
 			results <- result
+		case <-stop:
+			return
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-// makeRequest executa uma única requisição HTTP
-func (e *Executor) makeRequest(ctx context.Context, url string) models.RequestResult {
+// makeRequest executa uma única requisição HTTP. A duração cobre a requisição
+// completa, incluindo a leitura do corpo da resposta. A resposta retornada é
+// nil quando a requisição falha antes de recebê-la
+func (e *Executor) makeRequest(ctx context.Context, user *virtualUser, spec models.RequestSpec) (models.RequestResult, *response) {
 	start := time.Now()
+	phases := newPhaseRecorder()
+
+	var body io.Reader
+	if len(spec.Body) > 0 {
+		body = bytes.NewReader(spec.Body)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	// O registrador também fica no contexto para que a seleção de proxy do
+	// transporte indique quando a requisição passa por um proxy
+	traceCtx := context.WithValue(httptrace.WithClientTrace(ctx, phases.trace()), recorderKey{}, phases)
+	req, err := http.NewRequestWithContext(traceCtx, spec.Method, spec.URL, body)
 	if err != nil {
 		return models.RequestResult{
+			Timestamp:  start,
 			StatusCode: 0,
 			Duration:   time.Since(start),
 			Error:      err,
-		}
+		}, nil
 	}
 
-	// Adiciona User-Agent para identificar o stress test
+	// Adiciona User-Agent para identificar o stress test, que pode ser
+	// substituído pelos cabeçalhos configurados
 	req.Header.Set("User-Agent", "StressTest-CLI/1.0")
+	for name, values := range spec.Headers {
+		req.Header[name] = values
+	}
+
+	// As credenciais configuradas não substituem um cabeçalho Authorization
+	// definido na própria requisição (por exemplo, extraído em um fluxo)
+	if user.auth != nil && req.Header.Get("Authorization") == "" {
+		user.auth.Apply(req.Header)
+	}
 
-	resp, err := e.client.Do(req)
-	duration := time.Since(start)
+	// O cabeçalho Host precisa ser atribuído ao campo da requisição
+	if host := spec.Headers.Get("Host"); host != "" {
+		req.Host = host
+	}
 
+	resp, err := user.client.Do(req)
 	if err != nil {
 		return models.RequestResult{
+			Timestamp:  start,
 			StatusCode: 0,
-			Duration:   duration,
+			Duration:   time.Since(start),
 			Error:      err,
-		}
+			Timings:    phases.timings(time.Now()),
+		}, nil
 	}
 	defer resp.Body.Close()
 
 	// Lê o corpo da resposta para calcular o tamanho
 	bodyBytes, err := io.ReadAll(resp.Body)
+	end := time.Now()
 	responseSize := int64(len(bodyBytes))
 
-	if err != nil {
-		return models.RequestResult{
-			StatusCode:   resp.StatusCode,
-			Duration:     duration,
-			Error:        err,
-			ResponseSize: responseSize,
-		}
-	}
-
 	return models.RequestResult{
+		Timestamp:    start,
 		StatusCode:   resp.StatusCode,
-		Duration:     duration,
-		Error:        nil,
+		Duration:     end.Sub(start),
+		Error:        err,
 		ResponseSize: responseSize,
-	}
+		Timings:      phases.timings(end),
+	}, &response{header: resp.Header, body: bodyBytes}
 }
 
-// generateReport cria o relatório consolidado dos resultados
-func (e *Executor) generateReport(results []models.RequestResult, totalTime time.Duration, expectedRequests int) models.TestReport {
-	report := models.TestReport{
-		TotalTime:     totalTime,
-		TotalRequests: len(results),
-		StatusCodes:   make(map[int]int),
-	}
-
-	var totalDuration time.Duration
-	var totalDataTransfer int64
-	minDuration := time.Duration(^uint64(0) >> 1) // Max duration
-	maxDuration := time.Duration(0)
+// distributionBuckets é o número de faixas da distribuição dos tempos de resposta
+const distributionBuckets = 40
 
-	for _, result := range results {
-		// Contabiliza códigos de status
-		report.StatusCodes[result.StatusCode]++
+// curvePercentiles são os pontos da curva de percentis, mais densos na cauda
+var curvePercentiles = []float64{0, 10, 20, 30, 40, 50, 60, 70, 75, 80, 85, 90, 95, 97.5, 99, 99.5, 99.9, 99.99, 100}
 
-		// Contabiliza sucessos (2xx) e falhas
-		if result.StatusCode >= 200 && result.StatusCode < 300 && result.Error == nil {
-			report.SuccessfulReqs++
-		} else {
-			report.FailedReqs++
-		}
-
-		// Calcula estatísticas de tempo de resposta
-		totalDuration += result.Duration
-		totalDataTransfer += result.ResponseSize
-
-		if result.Duration < minDuration {
-			minDuration = result.Duration
-		}
-		if result.Duration > maxDuration {
-			maxDuration = result.Duration
-		}
+// latencyDistribution agrupa os tempos de resposta de um histograma em faixas
+func latencyDistribution(h *metrics.Histogram) []models.HistogramBucket {
+	var buckets []models.HistogramBucket
+	for _, bucket := range h.Distribution(distributionBuckets) {
+		buckets = append(buckets, models.HistogramBucket{Lower: bucket.Lower, Upper: bucket.Upper, Count: bucket.Count})
 	}
+	return buckets
+}
 
-	// Calcula médias e outras métricas
-	if len(results) > 0 {
-		report.AvgResponseTime = totalDuration / time.Duration(len(results))
-		report.MinResponseTime = minDuration
-		report.MaxResponseTime = maxDuration
-		report.RequestsPerSec = float64(len(results)) / totalTime.Seconds()
-		report.TotalDataTransfer = totalDataTransfer
+// percentileCurve extrai os tempos de resposta de cada ponto da curva de percentis
+func percentileCurve(h *metrics.Histogram) []models.PercentilePoint {
+	curve := make([]models.PercentilePoint, len(curvePercentiles))
+	for i, p := range curvePercentiles {
+		curve[i] = models.PercentilePoint{Percentile: p, Latency: h.Quantile(p / 100)}
 	}
+	return curve
+}
 
-	return report
+// isSuccess indica se a requisição foi bem-sucedida: sem erro, com status 2xx
+// (ou um dos esperados pelas verificações) e aprovada em todas as verificações
+func isSuccess(result models.RequestResult) bool {
+	if result.Error != nil || len(result.FailedChecks) > 0 {
+		return false
+	}
+	if result.StatusChecked {
+		return true
+	}
+	return result.StatusCode >= 200 && result.StatusCode < 300
 }
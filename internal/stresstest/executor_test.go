@@ -0,0 +1,313 @@
+package stresstest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// newDelayServer cria um servidor de teste que responde 200 após o atraso informado
+func newDelayServer(t *testing.T, delay time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runTest executa o teste sem exibir o progresso e falha em caso de erro
+func runTest(t *testing.T, config models.TestConfig) *models.StressTestResult {
+	t.Helper()
+	if config.Method == "" {
+		config.Method = http.MethodGet
+	}
+
+	executor := NewExecutor()
+	executor.SetOutput(io.Discard)
+	result, err := executor.Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return result
+}
+
+func TestRunDuration(t *testing.T) {
+	server := newDelayServer(t, 5*time.Millisecond)
+
+	result := runTest(t, models.TestConfig{
+		URL:             server.URL,
+		Concurrency:     2,
+		Duration:        300 * time.Millisecond,
+		MetricsInterval: 100 * time.Millisecond,
+	})
+	report := result.Report
+
+	if report.TotalTime < 300*time.Millisecond || report.TotalTime > time.Second {
+		t.Errorf("Expected the test to last about 300ms, got %v", report.TotalTime)
+	}
+	if report.TotalRequests < 10 || report.SuccessfulReqs != report.TotalRequests {
+		t.Errorf("Expected at least 10 successful requests, got %d of %d", report.SuccessfulReqs, report.TotalRequests)
+	}
+	if result.Results != nil {
+		t.Errorf("Expected no individual results without KeepResults, got %d", len(result.Results))
+	}
+
+	// A série temporal cobre o teste em intervalos de 100ms e soma todas as requisições
+	if len(report.TimeSeries) < 3 {
+		t.Fatalf("Expected at least 3 intervals, got %d", len(report.TimeSeries))
+	}
+	total := 0
+	for i, interval := range report.TimeSeries {
+		if interval.Offset != time.Duration(i)*100*time.Millisecond {
+			t.Errorf("Expected interval %d to start at %v, got %v", i, time.Duration(i)*100*time.Millisecond, interval.Offset)
+		}
+		total += interval.Requests
+	}
+	if total != report.TotalRequests {
+		t.Errorf("Expected the time series to count %d requests, got %d", report.TotalRequests, total)
+	}
+}
+
+func TestRunDurationEndsAtRequestLimit(t *testing.T) {
+	server := newDelayServer(t, 0)
+
+	report := runTest(t, models.TestConfig{
+		URL:         server.URL,
+		Requests:    5,
+		Concurrency: 2,
+		Duration:    10 * time.Second,
+	}).Report
+
+	if report.TotalRequests != 5 {
+		t.Errorf("Expected 5 requests, got %d", report.TotalRequests)
+	}
+	if report.TotalTime > 2*time.Second {
+		t.Errorf("Expected the test to end at the request limit, took %v", report.TotalTime)
+	}
+}
+
+func TestDispatchRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config models.TestConfig
+		behind time.Duration
+		// free é o número de iterações que os workers conseguem receber
+		free    int
+		dropped int
+		late    int
+	}{
+		{name: "workers livres", config: models.TestConfig{Rate: 200, Requests: 10}, free: 10},
+		{name: "sem workers livres", config: models.TestConfig{Rate: 200, Requests: 10}, dropped: 10},
+		{name: "parte dos workers livres", config: models.TestConfig{Rate: 200, Requests: 10}, free: 4, dropped: 6},
+		{name: "limitado pela duração", config: models.TestConfig{Rate: 100, Duration: 50 * time.Millisecond}, dropped: 5},
+		// Com o início no passado todas as iterações já estão vencidas
+		{name: "atrasado", config: models.TestConfig{Rate: 100, Requests: 10}, behind: time.Second, free: 10, late: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// O buffer do canal faz o papel dos workers livres, sem depender
+			// do escalonamento de goroutines
+			jobs := make(chan job, tt.free)
+
+			e := NewExecutor()
+			stats := e.dispatchRate(context.Background(), tt.config, time.Now().Add(-tt.behind), jobs)
+
+			if stats.dropped != tt.dropped {
+				t.Errorf("Expected %d dropped iterations, got %d", tt.dropped, stats.dropped)
+			}
+			if stats.late != tt.late {
+				t.Errorf("Expected %d late iterations, got %d", tt.late, stats.late)
+			}
+			if len(jobs) != tt.free {
+				t.Errorf("Expected %d dispatched iterations, got %d", tt.free, len(jobs))
+			}
+		})
+	}
+}
+
+// runScheduledJob executa no worker uma única iteração planejada com o atraso
+// informado, como no modo de taxa constante, e retorna os resultados enviados
+func runScheduledJob(t *testing.T, config models.TestConfig, scheduled time.Time) []models.RequestResult {
+	t.Helper()
+	e := NewExecutor()
+	work, err := newWorkload(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	work.httpClient, err = e.runClient(config.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := make(chan job, 1)
+	jobs <- job{scheduled: scheduled}
+	close(jobs)
+	results := make(chan models.RequestResult, len(config.Flow)+1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	e.worker(context.Background(), 1, work, jobs, results, nil, &wg)
+	close(results)
+
+	var collected []models.RequestResult
+	for result := range results {
+		collected = append(collected, result)
+	}
+	return collected
+}
+
+func TestWorkerMeasuresFromScheduledTime(t *testing.T) {
+	server := newDelayServer(t, 0)
+
+	// A iteração foi planejada 100ms antes de o worker recebê-la: o atraso
+	// entra na latência, evitando a omissão coordenada
+	scheduled := time.Now().Add(-100 * time.Millisecond)
+	result := runScheduledJob(t, models.TestConfig{URL: server.URL, Method: http.MethodGet}, scheduled)[0]
+
+	if !result.Timestamp.Equal(scheduled) {
+		t.Errorf("Expected the timestamp to be the scheduled time %v, got %v", scheduled, result.Timestamp)
+	}
+	if result.Duration < 100*time.Millisecond {
+		t.Errorf("Expected the latency to include the 100ms dispatch delay, got %v", result.Duration)
+	}
+}
+
+func TestWorkerChecksLatencyFromScheduledTime(t *testing.T) {
+	server := newDelayServer(t, 0)
+	checks := &models.Checks{MaxLatency: 50 * time.Millisecond}
+
+	tests := []struct {
+		name   string
+		config models.TestConfig
+	}{
+		{
+			name:   "request",
+			config: models.TestConfig{URL: server.URL, Method: http.MethodGet, Checks: checks},
+		},
+		{
+			name: "flow",
+			config: models.TestConfig{Checks: checks, Flow: []models.FlowStep{
+				{Request: models.RequestSpec{Name: "login", Method: http.MethodGet, URL: server.URL}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A resposta é imediata, mas a iteração esperou 100ms na fila: a
+			// verificação reprova a mesma latência exibida no relatório
+			result := runScheduledJob(t, tt.config, time.Now().Add(-100*time.Millisecond))[0]
+
+			if result.Duration < 100*time.Millisecond {
+				t.Fatalf("Expected the latency to include the 100ms dispatch delay, got %v", result.Duration)
+			}
+			if len(result.FailedChecks) != 1 || result.FailedChecks[0] != "latência ≤ 50ms" {
+				t.Errorf("Expected the latency check to fail, got %v", result.FailedChecks)
+			}
+		})
+	}
+}
+
+func TestRunRateDropsWhenWorkersAreBusy(t *testing.T) {
+	server := newDelayServer(t, 50*time.Millisecond)
+
+	// 100 req/s por 300ms são 30 iterações; um único worker ocupado por 50ms
+	// atende apenas parte delas e as demais são descartadas
+	report := runTest(t, models.TestConfig{
+		URL:         server.URL,
+		Rate:        100,
+		Concurrency: 1,
+		Duration:    300 * time.Millisecond,
+	}).Report
+
+	if report.DroppedIterations == 0 {
+		t.Error("Expected dropped iterations with a busy worker")
+	}
+	if report.TotalRequests+report.DroppedIterations != 30 {
+		t.Errorf("Expected 30 iterations, got %d requests and %d dropped", report.TotalRequests, report.DroppedIterations)
+	}
+}
+
+func TestRunRecordsPhasesAndPercentiles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	report := runTest(t, models.TestConfig{
+		URL:         server.URL,
+		Requests:    5,
+		Concurrency: 1,
+		Client:      models.ClientOptions{TLS: models.TLSOptions{Insecure: true}},
+	}).Report
+
+	if report.SuccessfulReqs != 5 {
+		t.Fatalf("Expected 5 successful requests, got %d", report.SuccessfulReqs)
+	}
+
+	// Um único worker reaproveita a conexão: conexão e TLS ocorrem uma vez
+	phases := report.Phases
+	if phases.Connect.Count != 1 || phases.TLS.Count != 1 {
+		t.Errorf("Expected one connect and one TLS handshake, got %d and %d", phases.Connect.Count, phases.TLS.Count)
+	}
+	if phases.TTFB.Count != 5 || phases.TTFB.Avg < 10*time.Millisecond {
+		t.Errorf("Expected 5 TTFB samples of at least 10ms, got %d averaging %v", phases.TTFB.Count, phases.TTFB.Avg)
+	}
+	if phases.DNS.Count != 0 {
+		t.Errorf("Expected no DNS lookup for an IP address, got %d", phases.DNS.Count)
+	}
+
+	p := report.Percentiles
+	ordered := []time.Duration{report.MinResponseTime, p.P50, p.P75, p.P90, p.P95, p.P99, p.P999, report.MaxResponseTime}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i] < ordered[i-1] {
+			t.Errorf("Expected ordered percentiles, got %v", ordered)
+			break
+		}
+	}
+	if p.P50 < 10*time.Millisecond {
+		t.Errorf("Expected the median to include the 10ms server delay, got %v", p.P50)
+	}
+}
+
+func TestTraceProxyMarksProxiedRequests(t *testing.T) {
+	// O proxy de teste responde diretamente às requisições encaminhadas
+	var forwarded string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.URL.String()
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	// Simula o proxy das variáveis de ambiente no transporte padrão
+	transport := &http.Transport{Proxy: http.ProxyURL(proxyURL)}
+	traceProxy(transport)
+	defer transport.CloseIdleConnections()
+
+	phases := newPhaseRecorder()
+	ctx := context.WithValue(httptrace.WithClientTrace(context.Background(), phases.trace()), recorderKey{}, phases)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://destino.invalid/saude", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected the request to go through the proxy, got %v", err)
+	}
+	resp.Body.Close()
+
+	if forwarded != "http://destino.invalid/saude" {
+		t.Errorf("Expected the proxy to receive the target URL, got %q", forwarded)
+	}
+	if !phases.proxied {
+		t.Error("Expected the request to be marked as proxied")
+	}
+}
@@ -0,0 +1,200 @@
+package stresstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"stresstest/internal/models"
+)
+
+// extractor obtém um valor da resposta de um passo do fluxo
+type extractor struct {
+	name    string
+	source  string
+	path    []pathSegment
+	pattern *regexp.Regexp
+	header  string
+}
+
+// pathSegment é um acesso a um campo de objeto ou a um índice de lista em um
+// caminho JSON
+type pathSegment struct {
+	key   string
+	index int
+	// isIndex indica um acesso por índice ([n]) em vez de por campo
+	isIndex bool
+}
+
+// newExtractor valida e compila uma extração
+func newExtractor(extraction models.Extraction) (extractor, error) {
+	x := extractor{name: extraction.Var, source: extraction.Source}
+	if x.name == "" {
+		return x, fmt.Errorf("extração sem nome de variável")
+	}
+
+	var err error
+	switch extraction.Source {
+	case models.ExtractJSON:
+		x.path, err = parseJSONPath(extraction.Expression)
+	case models.ExtractRegex:
+		x.pattern, err = regexp.Compile(extraction.Expression)
+	case models.ExtractHeader:
+		x.header = extraction.Expression
+		if x.header == "" {
+			err = fmt.Errorf("nome de cabeçalho vazio")
+		}
+	default:
+		err = fmt.Errorf("origem inválida %q: use %q, %q ou %q", extraction.Source,
+			models.ExtractJSON, models.ExtractRegex, models.ExtractHeader)
+	}
+	if err != nil {
+		return x, fmt.Errorf("extração %q inválida: %w", x.name, err)
+	}
+
+	return x, nil
+}
+
+// extractAll aplica as extrações à resposta e grava os valores nas variáveis.
+// O corpo é decodificado como JSON no máximo uma vez
+func extractAll(extractors []extractor, resp *response, vars map[string]string) error {
+	var document interface{}
+	decoded := false
+
+	for _, x := range extractors {
+		var value string
+		var err error
+
+		switch x.source {
+		case models.ExtractJSON:
+			if !decoded {
+				decoder := json.NewDecoder(bytes.NewReader(resp.body))
+				decoder.UseNumber()
+				if err := decoder.Decode(&document); err != nil {
+					return fmt.Errorf("extração de %q falhou: resposta não é um JSON válido", x.name)
+				}
+				decoded = true
+			}
+			value, err = lookupJSON(document, x.path)
+		case models.ExtractRegex:
+			match := x.pattern.FindSubmatch(resp.body)
+			switch {
+			case match == nil:
+				err = fmt.Errorf("expressão não encontrada")
+			case len(match) > 1:
+				value = string(match[1])
+			default:
+				value = string(match[0])
+			}
+		case models.ExtractHeader:
+			value = resp.header.Get(x.header)
+			if value == "" {
+				err = fmt.Errorf("cabeçalho %s ausente", x.header)
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("extração de %q falhou: %w", x.name, err)
+		}
+		vars[x.name] = value
+	}
+
+	return nil
+}
+
+// parseJSONPath interpreta caminhos como $.data.items[0].id; o prefixo $ é opcional
+func parseJSONPath(expression string) ([]pathSegment, error) {
+	path := strings.TrimPrefix(strings.TrimSpace(expression), "$")
+	var segments []pathSegment
+
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("caminho JSON inválido %q", expression)
+			}
+			segments = append(segments, pathSegment{key: path[:end]})
+			path = path[end:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("caminho JSON inválido %q", expression)
+			}
+			inner := path[1:end]
+			if index, err := strconv.Atoi(inner); err == nil && index >= 0 {
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			} else if key, err := strconv.Unquote(strings.ReplaceAll(inner, "'", "\"")); err == nil {
+				segments = append(segments, pathSegment{key: key})
+			} else {
+				return nil, fmt.Errorf("caminho JSON inválido %q", expression)
+			}
+			path = path[end+1:]
+		default:
+			// Permite omitir o ponto inicial (data.token)
+			if len(segments) > 0 {
+				return nil, fmt.Errorf("caminho JSON inválido %q", expression)
+			}
+			path = "." + path
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("caminho JSON vazio")
+	}
+
+	return segments, nil
+}
+
+// lookupJSON percorre o documento decodificado e retorna o valor como texto.
+// Objetos e listas são retornados em JSON
+func lookupJSON(document interface{}, path []pathSegment) (string, error) {
+	current := document
+	for _, segment := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment.key]
+			if segment.isIndex || !ok {
+				return "", fmt.Errorf("campo %s não encontrado", describeSegment(segment))
+			}
+			current = value
+		case []interface{}:
+			if !segment.isIndex || segment.index >= len(node) {
+				return "", fmt.Errorf("campo %s não encontrado", describeSegment(segment))
+			}
+			current = node[segment.index]
+		default:
+			return "", fmt.Errorf("campo %s não encontrado", describeSegment(segment))
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	case nil:
+		return "", fmt.Errorf("valor nulo")
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
+
+// describeSegment formata um segmento do caminho para mensagens de erro
+func describeSegment(segment pathSegment) string {
+	if segment.isIndex {
+		return fmt.Sprintf("[%d]", segment.index)
+	}
+	return segment.key
+}
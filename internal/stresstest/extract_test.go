@@ -0,0 +1,82 @@
+package stresstest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestExtractAll(t *testing.T) {
+	extractions := []models.Extraction{
+		{Var: "token", Source: models.ExtractJSON, Expression: "$.data.token"},
+		{Var: "first", Source: models.ExtractJSON, Expression: "data.items[1].id"},
+		{Var: "order", Source: models.ExtractRegex, Expression: `"order":"(\w+)"`},
+		{Var: "session", Source: models.ExtractHeader, Expression: "X-Session"},
+	}
+
+	var extractors []extractor
+	for _, extraction := range extractions {
+		x, err := newExtractor(extraction)
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", extraction.Var, err)
+		}
+		extractors = append(extractors, x)
+	}
+
+	resp := &response{
+		header: http.Header{"X-Session": []string{"s-1"}},
+		body:   []byte(`{"data":{"token":"abc","items":[{"id":1},{"id":42}]},"order":"o99"}`),
+	}
+
+	vars := make(map[string]string)
+	if err := extractAll(extractors, resp, vars); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{"token": "abc", "first": "42", "order": "o99", "session": "s-1"}
+	for name, value := range expected {
+		if vars[name] != value {
+			t.Errorf("Expected %s to be %q, got %q", name, value, vars[name])
+		}
+	}
+
+	missing, _ := newExtractor(models.Extraction{Var: "x", Source: models.ExtractJSON, Expression: "$.nope"})
+	if err := extractAll([]extractor{missing}, resp, vars); err == nil {
+		t.Error("Expected an error for a missing JSON field")
+	}
+}
+
+func TestRunCountsExtractionFailureAsFailedCheck(t *testing.T) {
+	server := newDelayServer(t, 0)
+
+	result := runTest(t, models.TestConfig{
+		Requests:    2,
+		Concurrency: 1,
+		Flow: []models.FlowStep{
+			{
+				Request: models.RequestSpec{Name: "login", Method: http.MethodGet, URL: server.URL},
+				Extract: []models.Extraction{{Var: "token", Source: models.ExtractJSON, Expression: "$.token"}},
+			},
+			{Request: models.RequestSpec{Name: "perfil", Method: http.MethodGet, URL: server.URL}},
+		},
+	})
+
+	report := result.Report
+	if len(report.ErrorCategories) != 0 {
+		t.Errorf("Expected no network errors, got %v", report.ErrorCategories)
+	}
+	if report.CheckFailures != 2 || report.FailedReqs != 2 {
+		t.Errorf("Expected 2 failed checks and requests, got %d and %d", report.CheckFailures, report.FailedReqs)
+	}
+	for check, count := range report.FailedChecks {
+		if !strings.HasPrefix(check, "login: extração de \"token\"") || count != 2 {
+			t.Errorf("Expected the login extraction to fail twice, got %q %d times", check, count)
+		}
+	}
+	// A jornada é interrompida no passo com falha
+	if report.Endpoints[1].TotalRequests != 0 || report.Journeys.FailedReqs != 2 {
+		t.Errorf("Expected 2 failed journeys stopping at login, got %+v", report.Journeys)
+	}
+}
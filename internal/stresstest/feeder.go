@@ -0,0 +1,60 @@
+package stresstest
+
+import (
+	"math/rand"
+	"sync/atomic"
+
+	"stresstest/internal/models"
+)
+
+// feederState entrega os registros de uma fonte de dados durante uma execução.
+// É seguro para uso concorrente
+type feederState struct {
+	feeder models.Feeder
+	next   atomic.Uint64
+}
+
+// record retorna o registro da próxima iteração do worker, ou false quando os
+// registros se esgotaram e a fonte está configurada para parar
+func (f *feederState) record(workerID int) (map[string]string, bool) {
+	records := f.feeder.Records
+	total := uint64(len(records))
+
+	var index uint64
+	switch f.feeder.Strategy {
+	case models.FeedRandom:
+		return records[rand.Intn(len(records))], true
+	case models.FeedUnique:
+		index = uint64(workerID - 1)
+	default:
+		index = f.next.Add(1) - 1
+	}
+
+	if index >= total {
+		if f.feeder.OnExhausted == models.ExhaustedStop {
+			return nil, false
+		}
+		index %= total
+	}
+
+	return records[index], true
+}
+
+// feed copia o próximo registro de cada fonte de dados para as variáveis do
+// usuário. Retorna false quando alguma fonte se esgotou, sinalizando o fim do
+// despacho de novas iterações
+func (w *workload) feed(user *virtualUser) bool {
+	for _, feeder := range w.feeders {
+		record, ok := feeder.record(user.template.WorkerID)
+		if !ok {
+			if !w.exhausted.Swap(true) {
+				w.stopDispatch()
+			}
+			return false
+		}
+		for name, value := range record {
+			user.template.Vars[name] = value
+		}
+	}
+	return true
+}
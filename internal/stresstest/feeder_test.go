@@ -0,0 +1,36 @@
+package stresstest
+
+import (
+	"testing"
+
+	"stresstest/internal/models"
+)
+
+func TestFeederStrategies(t *testing.T) {
+	records := []map[string]string{{"user": "ana"}, {"user": "bia"}}
+
+	sequential := &feederState{feeder: models.Feeder{Records: records, Strategy: models.FeedSequential, OnExhausted: models.ExhaustedRecycle}}
+	for i, expected := range []string{"ana", "bia", "ana"} {
+		record, ok := sequential.record(1)
+		if !ok || record["user"] != expected {
+			t.Errorf("Expected record %d to be %q, got %q (ok=%v)", i, expected, record["user"], ok)
+		}
+	}
+
+	stop := &feederState{feeder: models.Feeder{Records: records, Strategy: models.FeedSequential, OnExhausted: models.ExhaustedStop}}
+	stop.record(1)
+	stop.record(1)
+	if _, ok := stop.record(1); ok {
+		t.Error("Expected the feeder to be exhausted after all records")
+	}
+
+	unique := &feederState{feeder: models.Feeder{Records: records, Strategy: models.FeedUnique, OnExhausted: models.ExhaustedStop}}
+	for i := 0; i < 3; i++ {
+		if record, _ := unique.record(2); record["user"] != "bia" {
+			t.Errorf("Expected worker 2 to always get 'bia', got %q", record["user"])
+		}
+	}
+	if _, ok := unique.record(3); ok {
+		t.Error("Expected no record for a worker beyond the number of records")
+	}
+}
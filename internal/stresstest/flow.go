@@ -0,0 +1,185 @@
+package stresstest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"stresstest/internal/auth"
+	"stresstest/internal/models"
+	"stresstest/internal/templating"
+)
+
+// workload descreve o que cada iteração executa: uma requisição escolhida pelo
+// seletor ou, com um fluxo configurado, a jornada completa do usuário virtual
+type workload struct {
+	targets *targetSelector
+	// requests contém os templates de cada requisição do seletor, pelo índice
+	requests []requestTemplate
+	flow     []flowStep
+	feeders  []*feederState
+	client   models.ClientOptions
+	auth     auth.Provider
+	// httpClient é o cliente compartilhado da execução
+	httpClient *http.Client
+	// currentStage retorna o estágio do perfil de carga em andamento; nil sem
+	// perfil em estágios
+	currentStage func() int
+
+	// stopDispatch encerra o despacho de iterações quando uma fonte de dados se
+	// esgota; exhausted registra que isso ocorreu
+	stopDispatch func()
+	exhausted    atomic.Bool
+}
+
+// newWorkload prepara as requisições ou os passos do fluxo da configuração,
+// compilando os templates e validando as extrações
+func newWorkload(config models.TestConfig) (*workload, error) {
+	var feeders []*feederState
+	for _, feeder := range config.Feeders {
+		feeders = append(feeders, &feederState{feeder: feeder})
+	}
+
+	if len(config.Flow) == 0 {
+		w := &workload{targets: newTargetSelector(config), feeders: feeders, client: config.Client}
+		for _, spec := range w.targets.specs {
+			request, err := compileRequest(spec)
+			if err != nil {
+				return nil, fmt.Errorf("requisição %s: %w", spec.Name, err)
+			}
+			w.requests = append(w.requests, request)
+		}
+		return w, nil
+	}
+
+	w := &workload{feeders: feeders, client: config.Client}
+	for i, step := range config.Flow {
+		spec := withDefaults(step.Request, config)
+		request, err := compileRequest(spec)
+		if err != nil {
+			return nil, fmt.Errorf("passo %d (%s): %w", i+1, spec.Name, err)
+		}
+
+		compiled := flowStep{request: request}
+		for _, extraction := range step.Extract {
+			x, err := newExtractor(extraction)
+			if err != nil {
+				return nil, fmt.Errorf("passo %d (%s): %w", i+1, spec.Name, err)
+			}
+			compiled.extractors = append(compiled.extractors, x)
+		}
+		w.flow = append(w.flow, compiled)
+	}
+
+	return w, nil
+}
+
+// names retorna o nome exibido no relatório para cada requisição ou passo
+func (w *workload) names() []string {
+	if w.flow == nil {
+		return w.targets.names()
+	}
+
+	names := make([]string, len(w.flow))
+	for i, step := range w.flow {
+		names[i] = step.request.spec.Name
+	}
+	return names
+}
+
+// flowStep é um passo da jornada com os templates e as extrações já compilados
+type flowStep struct {
+	request    requestTemplate
+	extractors []extractor
+}
+
+// virtualUser guarda o estado de um worker: as variáveis dos templates, que
+// persistem entre os passos e as iterações, o cliente HTTP e as credenciais
+type virtualUser struct {
+	template templating.Context
+	client   *http.Client
+	// auth aplica as credenciais às requisições; nil sem autenticação
+	auth auth.Provider
+	// transport é o transporte exclusivo do usuário; nil quando compartilhado
+	transport *http.Transport
+}
+
+// newVirtualUser cria um usuário virtual sem variáveis
+func newVirtualUser(id int, counter *atomic.Uint64, client *http.Client, transport *http.Transport) *virtualUser {
+	return &virtualUser{
+		template: templating.Context{
+			WorkerID: id,
+			Vars:     make(map[string]string),
+			Counter:  counter,
+		},
+		client:    client,
+		transport: transport,
+	}
+}
+
+// close libera as conexões do transporte exclusivo do usuário
+func (u *virtualUser) close() {
+	if u.transport != nil {
+		u.transport.CloseIdleConnections()
+	}
+}
+
+// runJourney executa os passos do fluxo em ordem, enviando o resultado de cada
+// um. A jornada é interrompida no primeiro passo com falha, e o último
+// resultado enviado carrega a duração e o desfecho da jornada
+func (e *Executor) runJourney(ctx context.Context, steps []flowStep, user *virtualUser, j job, results chan<- models.RequestResult) {
+	start := time.Now()
+	// No modo de taxa constante a jornada começa no instante planejado
+	if !j.scheduled.IsZero() {
+		start = j.scheduled
+	}
+
+	for i, step := range steps {
+		sent := time.Now()
+
+		var result models.RequestResult
+		var resp *response
+		spec, err := step.request.render(&user.template)
+		if err != nil {
+			result = models.RequestResult{Timestamp: sent, Error: err}
+		} else {
+			e.inFlight.Add(1)
+			result, resp = e.makeRequest(ctx, user, spec)
+			e.inFlight.Add(-1)
+		}
+
+		// O atraso de despacho é atribuído ao primeiro passo, antes das
+		// verificações, que avaliam a mesma latência do relatório
+		if i == 0 && !j.scheduled.IsZero() {
+			result.Duration += sent.Sub(j.scheduled)
+			result.Timestamp = j.scheduled
+		}
+
+		step.request.checks.evaluate(&result, resp)
+		// Uma extração que falha reprova a resposta, que chegou normalmente:
+		// conta como verificação, não como erro de rede
+		if resp != nil && isSuccess(result) {
+			if err := extractAll(step.extractors, resp, user.template.Vars); err != nil {
+				result.FailedChecks = append(result.FailedChecks, err.Error())
+			}
+		}
+		result.Worker = user.template.WorkerID
+		result.Stage = j.stage
+		result.Target = i
+
+		success := isSuccess(result)
+		if !success || i == len(steps)-1 {
+			result.Journey = &models.JourneyResult{
+				Duration: time.Since(start),
+				Success:  success,
+			}
+		}
+		results <- result
+
+		if !success {
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package stresstest
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+)
+
+// sinkColumns são as colunas do arquivo CSV de resultados, na mesma ordem e
+// com os mesmos nomes dos campos do JSONL
+var sinkColumns = []string{
+	"timestamp", "worker", "name", "stage", "status", "success", "duration_ms",
+	"dns_ms", "connect_ms", "proxy_ms", "tls_ms", "ttfb_ms", "transfer_ms",
+	"bytes", "error_category", "error", "failed_checks",
+}
+
+// sinkRecord é uma linha do arquivo JSONL de resultados
+type sinkRecord struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Worker        int       `json:"worker"`
+	Name          string    `json:"name"`
+	Stage         int       `json:"stage"`
+	Status        int       `json:"status"`
+	Success       bool      `json:"success"`
+	DurationMs    float64   `json:"duration_ms"`
+	DNSMs         float64   `json:"dns_ms"`
+	ConnectMs     float64   `json:"connect_ms"`
+	ProxyMs       float64   `json:"proxy_ms"`
+	TLSMs         float64   `json:"tls_ms"`
+	TTFBMs        float64   `json:"ttfb_ms"`
+	TransferMs    float64   `json:"transfer_ms"`
+	Bytes         int64     `json:"bytes"`
+	ErrorCategory string    `json:"error_category"`
+	Error         string    `json:"error"`
+	FailedChecks  []string  `json:"failed_checks"`
+}
+
+// resultSink grava cada requisição no arquivo de resultados à medida que o
+// teste avança. Não é seguro para uso concorrente: é alimentado pela goroutine
+// coletora. Após o primeiro erro de escrita as gravações seguintes são
+// ignoradas e o erro é retornado por close
+type resultSink struct {
+	file    *os.File
+	buffer  *bufio.Writer
+	csv     *csv.Writer
+	encoder *json.Encoder
+	// names são os nomes das requisições, indexados por RequestResult.Target
+	names []string
+	err   error
+}
+
+// newResultSink cria o arquivo de resultados no formato informado (csv ou jsonl)
+func newResultSink(path, format string, names []string) (*resultSink, error) {
+	if format != models.ResultsCSV && format != models.ResultsJSONL {
+		return nil, fmt.Errorf("formato de resultados %q desconhecido: use csv ou jsonl", format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &resultSink{file: file, buffer: bufio.NewWriter(file), names: names}
+	if format == models.ResultsCSV {
+		s.csv = csv.NewWriter(s.buffer)
+		s.err = s.csv.Write(sinkColumns)
+	} else {
+		s.encoder = json.NewEncoder(s.buffer)
+		s.encoder.SetEscapeHTML(false)
+	}
+
+	return s, nil
+}
+
+// write grava uma requisição
+func (s *resultSink) write(result models.RequestResult) {
+	if s.err != nil {
+		return
+	}
+
+	record := newSinkRecord(result, s.names)
+	if s.encoder != nil {
+		s.err = s.encoder.Encode(record)
+		return
+	}
+
+	row := []string{
+		record.Timestamp.Format(time.RFC3339Nano),
+		strconv.Itoa(record.Worker),
+		record.Name,
+		strconv.Itoa(record.Stage),
+		strconv.Itoa(record.Status),
+		strconv.FormatBool(record.Success),
+	}
+	// Os milissegundos de metrics.Millis têm precisão de microssegundos: três casas
+	for _, ms := range []float64{
+		record.DurationMs, record.DNSMs, record.ConnectMs, record.ProxyMs,
+		record.TLSMs, record.TTFBMs, record.TransferMs,
+	} {
+		row = append(row, strconv.FormatFloat(ms, 'f', 3, 64))
+	}
+	row = append(row,
+		strconv.FormatInt(record.Bytes, 10),
+		record.ErrorCategory,
+		record.Error,
+		strings.Join(record.FailedChecks, "; "),
+	)
+	s.err = s.csv.Write(row)
+}
+
+// close grava os dados pendentes, fecha o arquivo e retorna o primeiro erro
+// de escrita
+func (s *resultSink) close() error {
+	if s.csv != nil && s.err == nil {
+		s.csv.Flush()
+		s.err = s.csv.Error()
+	}
+	if s.err == nil {
+		s.err = s.buffer.Flush()
+	}
+	if err := s.file.Close(); s.err == nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// newSinkRecord converte uma requisição para o formato do arquivo de resultados
+func newSinkRecord(result models.RequestResult, names []string) sinkRecord {
+	record := sinkRecord{
+		Timestamp:    result.Timestamp,
+		Worker:       result.Worker,
+		Stage:        result.Stage,
+		Status:       result.StatusCode,
+		Success:      isSuccess(result),
+		DurationMs:   metrics.Millis(result.Duration),
+		DNSMs:        metrics.Millis(result.Timings.DNS),
+		ConnectMs:    metrics.Millis(result.Timings.Connect),
+		ProxyMs:      metrics.Millis(result.Timings.Proxy),
+		TLSMs:        metrics.Millis(result.Timings.TLS),
+		TTFBMs:       metrics.Millis(result.Timings.TTFB),
+		TransferMs:   metrics.Millis(result.Timings.Transfer),
+		Bytes:        result.ResponseSize,
+		FailedChecks: result.FailedChecks,
+	}
+	if record.FailedChecks == nil {
+		record.FailedChecks = []string{}
+	}
+	if result.Target < len(names) {
+		record.Name = names[result.Target]
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+		record.ErrorCategory = categorizeError(record.Error)
+	}
+	return record
+}
+
+// resultsFormat deduz o formato do arquivo de resultados pela extensão quando
+// não é informado
+func resultsFormat(path, format string) string {
+	if format != "" {
+		return format
+	}
+	lower := strings.ToLower(path)
+	if strings.HasSuffix(lower, ".jsonl") || strings.HasSuffix(lower, ".ndjson") {
+		return models.ResultsJSONL
+	}
+	return models.ResultsCSV
+}
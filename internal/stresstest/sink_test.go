@@ -0,0 +1,108 @@
+package stresstest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestResultSink(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"login", "perfil"}
+	results := []models.RequestResult{
+		{Timestamp: time.Unix(0, 0).UTC(), Worker: 3, Target: 1, StatusCode: 200, Duration: 1500 * time.Microsecond, ResponseSize: 42},
+		{Timestamp: time.Unix(1, 0).UTC(), Worker: 1, Error: errors.New("dial tcp: connection refused")},
+	}
+
+	for _, format := range []string{models.ResultsCSV, models.ResultsJSONL} {
+		path := filepath.Join(dir, "resultados."+format)
+		sink, err := newResultSink(path, resultsFormat(path, ""), names)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, result := range results {
+			sink.write(result)
+		}
+		if err := sink.close(); err != nil {
+			t.Fatalf("Expected no error closing the %s sink, got %v", format, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if format == models.ResultsCSV {
+			rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+			if err != nil {
+				t.Fatalf("Expected valid CSV, got %v", err)
+			}
+			if len(rows) != 3 || rows[0][0] != "timestamp" {
+				t.Fatalf("Expected header and 2 rows, got %v", rows)
+			}
+			if rows[1][1] != "3" || rows[1][2] != "perfil" || rows[1][6] != "1.500" {
+				t.Errorf("Expected worker 3, name perfil and 1.500ms, got %v", rows[1])
+			}
+			if rows[2][14] != "Erros de Conexão Recusada" {
+				t.Errorf("Expected the error category, got %q", rows[2][14])
+			}
+			continue
+		}
+
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Expected 2 lines, got %d", len(lines))
+		}
+		var record sinkRecord
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		if record.Name != "perfil" || !record.Success || record.DurationMs != 1.5 || record.Bytes != 42 {
+			t.Errorf("Unexpected record %+v", record)
+		}
+	}
+
+	if _, err := newResultSink(filepath.Join(dir, "x"), "xml", names); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestRunKeepsResultWhenResultsFileFails(t *testing.T) {
+	// /dev/full aceita a criação, mas toda escrita falha por falta de espaço
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full indisponível")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	executor := NewExecutor()
+	executor.SetOutput(io.Discard)
+	result, err := executor.Run(context.Background(), models.TestConfig{
+		URL:         server.URL,
+		Method:      http.MethodGet,
+		Requests:    5,
+		Concurrency: 1,
+		ResultsFile: "/dev/full",
+	})
+	if err != nil {
+		t.Fatalf("Expected the test to complete, got %v", err)
+	}
+	if result.Report.TotalRequests != 5 {
+		t.Errorf("Expected 5 requests in the report, got %d", result.Report.TotalRequests)
+	}
+	if result.ResultsFileError == nil {
+		t.Error("Expected the results file error to be reported")
+	}
+}
@@ -0,0 +1,159 @@
+package stresstest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// stageControlInterval é o intervalo em que o controlador ajusta o número de workers
+const stageControlInterval = 100 * time.Millisecond
+
+// controlStages ajusta continuamente o número de workers ao alvo do perfil de
+// carga, iniciando novos workers ou encerrando os excedentes após a requisição
+// em andamento. Termina quando o despacho de iterações é concluído
+func (e *Executor) controlStages(ctx context.Context, config models.TestConfig, startTime time.Time, work *workload, jobs <-chan job, results chan<- models.RequestResult, dispatchDone <-chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	// Canais de parada dos workers ativos, do mais antigo ao mais recente
+	var active []chan struct{}
+	// Os identificadores dos workers são vagas reaproveitadas: um worker
+	// encerrado devolve a sua ao terminar a requisição em andamento. Assim os
+	// identificadores nunca passam do maior alvo do perfil e dois workers
+	// simultâneos nunca compartilham um registro exclusivo (estratégia unique)
+	slots := newSlotPool(MaxStageTarget(config.Stages))
+
+	ticker := time.NewTicker(stageControlInterval)
+	defer ticker.Stop()
+
+	for {
+		target, _ := stageAt(config.Stages, time.Since(startTime))
+
+		for len(active) < target {
+			// Sem vaga livre, os workers em encerramento ainda não terminaram:
+			// o novo worker é iniciado em um próximo ajuste
+			id, ok := slots.acquire()
+			if !ok {
+				break
+			}
+			stop := make(chan struct{})
+			active = append(active, stop)
+			wg.Add(1)
+			go func() {
+				defer slots.release(id)
+				e.worker(ctx, id, work, jobs, results, stop, wg)
+			}()
+		}
+
+		for len(active) > target {
+			last := len(active) - 1
+			close(active[last])
+			active = active[:last]
+		}
+
+		select {
+		case <-ticker.C:
+		case <-dispatchDone:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// slotPool guarda os identificadores livres dos workers do perfil em estágios.
+// É seguro para uso concorrente
+type slotPool struct {
+	mu   sync.Mutex
+	free []int
+}
+
+// newSlotPool cria um conjunto com os identificadores 1 a size livres
+func newSlotPool(size int) *slotPool {
+	p := &slotPool{free: make([]int, size)}
+	// Os menores identificadores ficam no topo da pilha e são usados primeiro
+	for i := range p.free {
+		p.free[i] = size - i
+	}
+	return p
+}
+
+// acquire reserva um identificador livre; false quando todos estão em uso
+func (p *slotPool) acquire() (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.free) == 0 {
+		return 0, false
+	}
+	id := p.free[len(p.free)-1]
+	p.free = p.free[:len(p.free)-1]
+	return id, true
+}
+
+// release devolve um identificador ao conjunto
+func (p *slotPool) release(id int) {
+	p.mu.Lock()
+	p.free = append(p.free, id)
+	p.mu.Unlock()
+}
+
+// MaxStageTarget retorna o maior número de usuários virtuais do perfil de
+// carga, que é também o maior número de workers simultâneos
+func MaxStageTarget(stages []models.Stage) int {
+	max := 0
+	for _, stage := range stages {
+		if stage.Target > max {
+			max = stage.Target
+		}
+	}
+	return max
+}
+
+// stageAt retorna o número de usuários virtuais planejado e o índice do estágio
+// no instante elapsed, interpolando linearmente a partir do alvo do estágio anterior
+func stageAt(stages []models.Stage, elapsed time.Duration) (int, int) {
+	from := 0
+	for i, stage := range stages {
+		if elapsed < stage.Duration {
+			progress := float64(elapsed) / float64(stage.Duration)
+			return from + int(math.Round(float64(stage.Target-from)*progress)), i
+		}
+		elapsed -= stage.Duration
+		from = stage.Target
+	}
+	return from, len(stages) - 1
+}
+
+// stagesDuration retorna a duração total do perfil de carga
+func stagesDuration(stages []models.Stage) time.Duration {
+	var total time.Duration
+	for _, stage := range stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// describeStages retorna uma descrição resumida do perfil de carga
+func describeStages(stages []models.Stage) string {
+	parts := make([]string, len(stages))
+	for i, stage := range stages {
+		parts[i] = fmt.Sprintf("%v→%d", stage.Duration, stage.Target)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stageNames retorna o nome de cada estágio exibido no relatório
+func stageNames(stages []models.Stage) []string {
+	names := make([]string, len(stages))
+	from := 0
+	for i, stage := range stages {
+		names[i] = fmt.Sprintf("Estágio %d: %d → %d VUs em %v", i+1, from, stage.Target, stage.Duration)
+		from = stage.Target
+	}
+	return names
+}
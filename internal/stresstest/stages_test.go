@@ -0,0 +1,195 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestStageAt(t *testing.T) {
+	stages := []models.Stage{
+		{Duration: 10 * time.Second, Target: 10},
+		{Duration: 20 * time.Second, Target: 10},
+		{Duration: 0, Target: 50},
+		{Duration: 10 * time.Second, Target: 0},
+	}
+
+	tests := []struct {
+		elapsed time.Duration
+		target  int
+		stage   int
+	}{
+		{0, 0, 0},
+		{5 * time.Second, 5, 0},
+		{9 * time.Second, 9, 0},
+		{10 * time.Second, 10, 1},
+		{29 * time.Second, 10, 1},
+		// O estágio de duração zero muda o alvo imediatamente
+		{30 * time.Second, 50, 3},
+		{35 * time.Second, 25, 3},
+		{40 * time.Second, 0, 3},
+		{time.Minute, 0, 3},
+	}
+
+	for _, tt := range tests {
+		target, stage := stageAt(stages, tt.elapsed)
+		if target != tt.target || stage != tt.stage {
+			t.Errorf("At %v expected %d VUs in stage %d, got %d in stage %d", tt.elapsed, tt.target, tt.stage, target, stage)
+		}
+	}
+}
+
+func TestRunStagesAddsAndRemovesWorkers(t *testing.T) {
+	server := newDelayServer(t, 5*time.Millisecond)
+
+	result := runTest(t, models.TestConfig{
+		URL: server.URL,
+		Stages: []models.Stage{
+			{Duration: 0, Target: 4},
+			{Duration: 300 * time.Millisecond, Target: 4},
+			{Duration: 0, Target: 1},
+			{Duration: 300 * time.Millisecond, Target: 1},
+		},
+		KeepResults: true,
+	})
+
+	if len(result.Report.Stages) != 4 {
+		t.Fatalf("Expected 4 stages in the report, got %d", len(result.Report.Stages))
+	}
+
+	workers := map[int]map[int]int{1: {}, 3: {}}
+	for _, r := range result.Results {
+		if counts, ok := workers[r.Stage]; ok {
+			counts[r.Worker]++
+		}
+	}
+	if len(workers[1]) != 4 {
+		t.Errorf("Expected 4 workers in the plateau, got %d", len(workers[1]))
+	}
+
+	// Após a redução resta o worker mais antigo. O controlador ajusta os
+	// workers a cada stageControlInterval, e os encerrados concluem a iteração
+	// que já haviam recebido: depois disso, só o worker 1 envia requisições
+	start := result.Results[0].Timestamp
+	for _, r := range result.Results {
+		if r.Timestamp.Before(start) {
+			start = r.Timestamp
+		}
+	}
+	settled := start.Add(300*time.Millisecond + 2*stageControlInterval)
+	others := 0
+	for _, r := range result.Results {
+		if r.Stage == 3 && r.Worker != 1 && r.Timestamp.After(settled) {
+			others++
+		}
+	}
+	if workers[3][1] < 10 || others > 0 {
+		t.Errorf("Expected the last stage to run on worker 1, got %v with %d late results from other workers", workers[3], others)
+	}
+}
+
+func TestStagesReuseWorkerSlotsForUniqueFeeders(t *testing.T) {
+	var mu sync.Mutex
+	// inUse conta as requisições em andamento de cada usuário do feeder
+	inUse := make(map[string]int)
+	shared := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := r.URL.Query().Get("user")
+		mu.Lock()
+		inUse[user]++
+		if inUse[user] > 1 {
+			shared = true
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inUse[user]--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	records := make([]map[string]string, 3)
+	for i := range records {
+		records[i] = map[string]string{"user": string(rune('a' + i))}
+	}
+
+	// Sobe a 3 usuários, desce a 0 e volta a 3: com a estratégia unique e
+	// parada ao esgotar, os 3 registros bastam durante todo o perfil
+	config := models.TestConfig{
+		URL:    server.URL + "/?user={{user}}",
+		Method: http.MethodGet,
+		Stages: []models.Stage{
+			{Duration: 0, Target: 3},
+			{Duration: 300 * time.Millisecond, Target: 3},
+			{Duration: 0, Target: 0},
+			{Duration: 200 * time.Millisecond, Target: 0},
+			{Duration: 0, Target: 3},
+			{Duration: 300 * time.Millisecond, Target: 3},
+		},
+		Feeders: []models.Feeder{{
+			Name:        "usuarios.csv",
+			Records:     records,
+			Strategy:    models.FeedUnique,
+			OnExhausted: models.ExhaustedStop,
+		}},
+		KeepResults: true,
+	}
+
+	result := runTest(t, config)
+	if result.Report.FeederExhausted {
+		t.Error("Expected the feeder not to be exhausted after ramping down and up again")
+	}
+	if shared {
+		t.Error("Expected no unique record to be used by two workers at the same time")
+	}
+
+	lastStage := len(config.Stages) - 1
+	workers := make(map[int]bool)
+	for _, r := range result.Results {
+		if r.Worker < 1 || r.Worker > 3 {
+			t.Errorf("Expected worker IDs between 1 and 3, got %d", r.Worker)
+		}
+		if r.Stage == lastStage {
+			workers[r.Worker] = true
+		}
+	}
+	if len(workers) != 3 {
+		t.Errorf("Expected 3 workers in the last stage, got %d", len(workers))
+	}
+}
+
+func TestRunStagesCreditsIterationsWhenReceived(t *testing.T) {
+	server := newDelayServer(t, 150*time.Millisecond)
+
+	// O único worker fica ocupado além do fim do primeiro estágio: a iteração
+	// seguinte espera por ele e só é entregue no segundo estágio
+	result := runTest(t, models.TestConfig{
+		URL: server.URL,
+		Stages: []models.Stage{
+			{Duration: 0, Target: 1},
+			{Duration: 100 * time.Millisecond, Target: 1},
+			{Duration: 200 * time.Millisecond, Target: 1},
+		},
+		KeepResults: true,
+	})
+
+	if len(result.Results) < 2 {
+		t.Fatalf("Expected at least 2 requests, got %d", len(result.Results))
+	}
+	start := result.Results[0].Timestamp
+	for i, r := range result.Results {
+		expected := 1
+		if r.Timestamp.Sub(start) >= 100*time.Millisecond {
+			expected = 2
+		}
+		if r.Stage != expected {
+			t.Errorf("Expected request %d, sent after %v, in stage %d, got %d", i+1, r.Timestamp.Sub(start), expected, r.Stage)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package stresstest
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"stresstest/internal/models"
+)
+
+// targetSelector escolhe a requisição de cada iteração entre as configuradas,
+// em ciclo ou por sorteio, respeitando os pesos. É seguro para uso concorrente
+type targetSelector struct {
+	specs  []models.RequestSpec
+	random bool
+	// cumulative contém a soma acumulada dos pesos, usada nas duas ordens
+	cumulative []int
+	total      int
+	next       atomic.Uint64
+}
+
+// newTargetSelector cria o seletor a partir da configuração. Sem Targets, a
+// única requisição é a descrita por URL, Method, Headers e Body
+func newTargetSelector(config models.TestConfig) *targetSelector {
+	specs := config.Targets
+	if len(specs) == 0 {
+		specs = []models.RequestSpec{requestSpec(config)}
+	}
+
+	selector := &targetSelector{random: config.TargetOrder == models.OrderRandom}
+	for _, spec := range specs {
+		spec = withDefaults(spec, config)
+		selector.specs = append(selector.specs, spec)
+		selector.total += spec.Weight
+		selector.cumulative = append(selector.cumulative, selector.total)
+	}
+
+	return selector
+}
+
+// pick retorna o índice e a requisição da próxima iteração
+func (s *targetSelector) pick() (int, models.RequestSpec) {
+	if len(s.specs) == 1 {
+		return 0, s.specs[0]
+	}
+
+	var position int
+	if s.random {
+		position = rand.Intn(s.total)
+	} else {
+		position = int((s.next.Add(1) - 1) % uint64(s.total))
+	}
+
+	index := sort.SearchInts(s.cumulative, position+1)
+	return index, s.specs[index]
+}
+
+// names retorna o nome exibido no relatório para cada requisição
+func (s *targetSelector) names() []string {
+	names := make([]string, len(s.specs))
+	for i, spec := range s.specs {
+		names[i] = spec.Name
+	}
+	return names
+}
+
+// requestSpec monta a requisição enviada pelos workers a partir da configuração
+func requestSpec(config models.TestConfig) models.RequestSpec {
+	return models.RequestSpec{
+		Method:  config.Method,
+		URL:     config.URL,
+		Headers: config.Headers,
+		Body:    config.Body,
+	}
+}
+
+// withDefaults completa uma requisição com os valores padrão da configuração:
+// método GET, peso 1, nome "MÉTODO URL" e os cabeçalhos globais, que podem ser
+// sobrescritos pelos cabeçalhos da própria requisição
+func withDefaults(spec models.RequestSpec, config models.TestConfig) models.RequestSpec {
+	if spec.Method == "" {
+		spec.Method = config.Method
+	}
+	if spec.Method == "" {
+		spec.Method = http.MethodGet
+	}
+	if spec.Weight <= 0 {
+		spec.Weight = 1
+	}
+	if spec.Name == "" {
+		spec.Name = fmt.Sprintf("%s %s", spec.Method, spec.URL)
+	}
+
+	headers := config.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	for name, values := range spec.Headers {
+		headers[name] = values
+	}
+	spec.Headers = headers
+
+	if spec.Checks == nil {
+		spec.Checks = config.Checks
+	}
+
+	return spec
+}
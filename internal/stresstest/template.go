@@ -0,0 +1,103 @@
+package stresstest
+
+import (
+	"net/http"
+
+	"stresstest/internal/models"
+	"stresstest/internal/templating"
+)
+
+// requestTemplate é uma requisição com URL, cabeçalhos e corpo compilados como
+// templates. Requisições sem expressões dinâmicas são reaproveitadas sem renderização
+type requestTemplate struct {
+	spec    models.RequestSpec
+	static  bool
+	url     *templating.Template
+	headers map[string][]*templating.Template
+	body    *templating.Template
+	// checks verifica as respostas; nil sem verificações
+	checks *checker
+}
+
+// compileRequest compila os templates e as verificações de uma requisição
+func compileRequest(spec models.RequestSpec) (requestTemplate, error) {
+	t := requestTemplate{spec: spec, static: true}
+
+	var err error
+	if t.checks, err = newChecker(spec.Checks); err != nil {
+		return t, err
+	}
+
+	if t.url, err = templating.Compile(spec.URL); err != nil {
+		return t, err
+	}
+	t.static = t.url.Static()
+
+	if len(spec.Body) > 0 {
+		if t.body, err = templating.Compile(string(spec.Body)); err != nil {
+			return t, err
+		}
+		t.static = t.static && t.body.Static()
+	}
+
+	t.headers = make(map[string][]*templating.Template, len(spec.Headers))
+	for name, values := range spec.Headers {
+		for _, value := range values {
+			compiled, err := templating.Compile(value)
+			if err != nil {
+				return t, err
+			}
+			t.headers[name] = append(t.headers[name], compiled)
+			t.static = t.static && compiled.Static()
+		}
+	}
+
+	// Uma requisição estática é renderizada uma única vez, o que aplica os
+	// escapes (\{{) ao texto reaproveitado em todas as requisições
+	if t.static {
+		if t.spec, err = t.renderTemplates(&templating.Context{}); err != nil {
+			return t, err
+		}
+	}
+
+	return t, nil
+}
+
+// render gera a requisição enviada para o contexto do usuário virtual
+func (t requestTemplate) render(ctx *templating.Context) (models.RequestSpec, error) {
+	if t.static {
+		return t.spec, nil
+	}
+	return t.renderTemplates(ctx)
+}
+
+// renderTemplates renderiza a URL, o corpo e os cabeçalhos da requisição
+func (t requestTemplate) renderTemplates(ctx *templating.Context) (models.RequestSpec, error) {
+	spec := t.spec
+
+	var err error
+	if spec.URL, err = t.url.Render(ctx); err != nil {
+		return spec, err
+	}
+
+	if t.body != nil {
+		body, err := t.body.Render(ctx)
+		if err != nil {
+			return spec, err
+		}
+		spec.Body = []byte(body)
+	}
+
+	spec.Headers = make(http.Header, len(t.headers))
+	for name, templates := range t.headers {
+		values := make([]string, len(templates))
+		for i, template := range templates {
+			if values[i], err = template.Render(ctx); err != nil {
+				return spec, err
+			}
+		}
+		spec.Headers[name] = values
+	}
+
+	return spec, nil
+}
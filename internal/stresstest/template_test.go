@@ -0,0 +1,99 @@
+package stresstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"stresstest/internal/models"
+	"stresstest/internal/templating"
+)
+
+func TestCompileRequestUnescapesStaticRequests(t *testing.T) {
+	request, err := compileRequest(models.RequestSpec{
+		Method:  http.MethodPost,
+		URL:     `http://localhost/\{{id}}`,
+		Headers: http.Header{"X-Modelo": {`\{{valor}}`}},
+		Body:    []byte(`{"t":"\{{name}}"}`),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !request.static {
+		t.Fatal("Expected a request with only escapes to be static")
+	}
+
+	spec, err := request.render(&templating.Context{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec.URL != "http://localhost/{{id}}" {
+		t.Errorf("Expected the URL without the escape, got %q", spec.URL)
+	}
+	if got := spec.Headers.Get("X-Modelo"); got != "{{valor}}" {
+		t.Errorf("Expected the header without the escape, got %q", got)
+	}
+	if string(spec.Body) != `{"t":"{{name}}"}` {
+		t.Errorf("Expected the body without the escape, got %q", spec.Body)
+	}
+}
+
+func TestRenderUserVariables(t *testing.T) {
+	request, err := compileRequest(models.RequestSpec{
+		Method:  http.MethodGet,
+		URL:     "http://localhost/users/{{id}}?t={{ token }}",
+		Headers: http.Header{"Authorization": {"Bearer {{token}}"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := &templating.Context{Vars: map[string]string{"token": "abc", "id": "7"}}
+	spec, err := request.render(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec.URL != "http://localhost/users/7?t=abc" {
+		t.Errorf("Expected 'http://localhost/users/7?t=abc', got %q", spec.URL)
+	}
+	if got := spec.Headers.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Expected 'Bearer abc', got %q", got)
+	}
+
+	delete(ctx.Vars, "token")
+	if _, err := request.render(ctx); err == nil {
+		t.Error("Expected an error for an undefined variable")
+	}
+}
+
+func TestRunReportsUndefinedFlowVariable(t *testing.T) {
+	var mu sync.Mutex
+	paths := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path]++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	report := runTest(t, models.TestConfig{
+		Requests:    2,
+		Concurrency: 1,
+		Flow: []models.FlowStep{
+			{Request: models.RequestSpec{Name: "login", Method: http.MethodGet, URL: server.URL + "/login"}},
+			{Request: models.RequestSpec{Name: "perfil", Method: http.MethodGet, URL: server.URL + "/perfil/{{missing}}"}},
+		},
+	}).Report
+
+	// O passo com a variável indefinida não chega a ser enviado
+	if paths["/login"] != 2 || len(paths) != 1 {
+		t.Errorf("Expected only the login step to reach the server, got %v", paths)
+	}
+	if report.Journeys.FailedReqs != 2 || report.Endpoints[1].FailedReqs != 2 {
+		t.Errorf("Expected the second step of both journeys to fail, got %+v", report.Endpoints[1])
+	}
+	if report.ErrorCategories[`Variável "Missing" Não Definida`] != 2 {
+		t.Errorf("Expected the undefined variable error twice, got %v", report.ErrorCategories)
+	}
+}
@@ -0,0 +1,118 @@
+package stresstest
+
+import (
+	"time"
+
+	"stresstest/internal/metrics"
+	"stresstest/internal/models"
+)
+
+const (
+	// defaultMetricsInterval é a largura padrão de cada intervalo da série temporal
+	defaultMetricsInterval = time.Second
+
+	// inFlightSampleInterval é o intervalo de amostragem das requisições em andamento
+	inFlightSampleInterval = 100 * time.Millisecond
+)
+
+// timeSeries acumula as métricas do teste por intervalo de tempo. Apenas os
+// intervalos mais recentes mantêm um histograma: os anteriores têm seus
+// percentis calculados e o histograma descartado, mantendo a memória constante
+// mesmo em testes longos
+type timeSeries struct {
+	start     time.Time
+	interval  time.Duration
+	intervals []*intervalStats
+}
+
+// intervalStats acumula as métricas de um intervalo
+type intervalStats struct {
+	summary models.IntervalMetrics
+	// latencies é nil depois que o intervalo é finalizado
+	latencies *metrics.Histogram
+}
+
+// newTimeSeries cria uma série temporal a partir do início do teste
+func newTimeSeries(start time.Time, interval time.Duration) *timeSeries {
+	if interval <= 0 {
+		interval = defaultMetricsInterval
+	}
+	return &timeSeries{start: start, interval: interval}
+}
+
+// add atribui uma requisição ao intervalo em que terminou
+func (t *timeSeries) add(result models.RequestResult) {
+	stats := t.at(result.Timestamp.Add(result.Duration))
+	stats.summary.Requests++
+	if !isSuccess(result) {
+		stats.summary.FailedReqs++
+	}
+	stats.summary.Bytes += result.ResponseSize
+
+	// Resultados tardios de intervalos já finalizados entram apenas nos contadores
+	if stats.latencies != nil {
+		stats.latencies.Record(result.Duration)
+	}
+}
+
+// observeInFlight registra uma amostra do número de requisições em andamento
+func (t *timeSeries) observeInFlight(at time.Time, inFlight int) {
+	stats := t.at(at)
+	if inFlight > stats.summary.InFlight {
+		stats.summary.InFlight = inFlight
+	}
+}
+
+// at retorna o intervalo que contém o instante, criando os intervalos
+// necessários e finalizando os que ficaram para trás
+func (t *timeSeries) at(instant time.Time) *intervalStats {
+	index := 0
+	if elapsed := instant.Sub(t.start); elapsed > 0 {
+		index = int(elapsed / t.interval)
+	}
+
+	for len(t.intervals) <= index {
+		t.intervals = append(t.intervals, &intervalStats{
+			summary:   models.IntervalMetrics{Offset: time.Duration(len(t.intervals)) * t.interval},
+			latencies: metrics.NewHistogram(),
+		})
+	}
+
+	// Mantém abertos apenas o intervalo atual e o anterior
+	for i := index - 2; i >= 0 && t.intervals[i].latencies != nil; i-- {
+		t.intervals[i].finalize()
+	}
+
+	return t.intervals[index]
+}
+
+// finalize calcula os percentis do intervalo e descarta o histograma
+func (s *intervalStats) finalize() {
+	s.summary.P50 = s.latencies.Quantile(0.50)
+	s.summary.P95 = s.latencies.Quantile(0.95)
+	s.summary.P99 = s.latencies.Quantile(0.99)
+	s.latencies = nil
+}
+
+// report gera a série temporal; o último intervalo é proporcional ao tempo
+// restante do teste
+func (t *timeSeries) report(totalTime time.Duration) []models.IntervalMetrics {
+	series := make([]models.IntervalMetrics, len(t.intervals))
+	for i, stats := range t.intervals {
+		if stats.latencies != nil {
+			stats.finalize()
+		}
+
+		summary := stats.summary
+		width := t.interval
+		if remaining := totalTime - summary.Offset; remaining > 0 && remaining < width {
+			width = remaining
+		}
+		summary.RequestsPerSec = float64(summary.Requests) / width.Seconds()
+		if summary.Requests > 0 {
+			summary.ErrorRate = float64(summary.FailedReqs) / float64(summary.Requests) * 100
+		}
+		series[i] = summary
+	}
+	return series
+}
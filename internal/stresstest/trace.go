@@ -0,0 +1,124 @@
+package stresstest
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// recorderKey identifica o registrador de fases no contexto da requisição
+type recorderKey struct{}
+
+// phaseRecorder registra os instantes de cada fase de uma requisição via
+// httptrace. Os callbacks de conexão podem ser chamados a partir de outras
+// goroutines (tentativas de conexão em paralelo), por isso o acesso é protegido
+type phaseRecorder struct {
+	mu sync.Mutex
+	// proxied indica que a requisição foi encaminhada por um proxy
+	proxied      bool
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+// newPhaseRecorder cria um registrador de fases vazio
+func newPhaseRecorder() *phaseRecorder {
+	return &phaseRecorder{}
+}
+
+// trace retorna os callbacks de httptrace que alimentam o registrador
+func (p *phaseRecorder) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			p.mark(&p.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			p.mark(&p.dnsDone)
+		},
+		ConnectStart: func(network, addr string) {
+			// Com várias tentativas em paralelo vale o início da primeira
+			p.mu.Lock()
+			if p.connectStart.IsZero() {
+				p.connectStart = time.Now()
+			}
+			p.mu.Unlock()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			if err == nil {
+				p.mark(&p.connectDone)
+			}
+		},
+		TLSHandshakeStart: func() {
+			p.mark(&p.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			p.mark(&p.tlsDone)
+		},
+		GotConn: func(httptrace.GotConnInfo) {
+			p.mark(&p.gotConn)
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			p.mark(&p.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			p.mark(&p.firstByte)
+		},
+	}
+}
+
+// markProxied registra que a requisição foi encaminhada por um proxy
+func (p *phaseRecorder) markProxied() {
+	p.mu.Lock()
+	p.proxied = true
+	p.mu.Unlock()
+}
+
+// mark registra o instante atual em um dos campos do registrador
+func (p *phaseRecorder) mark(field *time.Time) {
+	p.mu.Lock()
+	*field = time.Now()
+	p.mu.Unlock()
+}
+
+// timings calcula a duração de cada fase; end é o fim da leitura do corpo
+func (p *phaseRecorder) timings(end time.Time) models.PhaseTimings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Com proxy, o túnel (CONNECT) ou o handshake SOCKS ocorre entre a conexão
+	// TCP com o proxy e o handshake TLS ou, sem TLS, a entrega da conexão
+	var proxy time.Duration
+	if p.proxied {
+		tunnelDone := p.tlsStart
+		if tunnelDone.IsZero() {
+			tunnelDone = p.gotConn
+		}
+		proxy = between(p.connectDone, tunnelDone)
+	}
+
+	return models.PhaseTimings{
+		DNS:      between(p.dnsStart, p.dnsDone),
+		Connect:  between(p.connectStart, p.connectDone),
+		Proxy:    proxy,
+		TLS:      between(p.tlsStart, p.tlsDone),
+		TTFB:     between(p.wroteRequest, p.firstByte),
+		Transfer: between(p.firstByte, end),
+	}
+}
+
+// between retorna o intervalo entre dois instantes, ou zero se algum não ocorreu
+func between(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
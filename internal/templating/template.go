@@ -0,0 +1,272 @@
+// Package templating implementa os templates de URL, cabeçalhos e corpo das
+// requisições. Os templates são compilados uma única vez e renderizados a cada
+// requisição, com funções embutidas para gerar valores dinâmicos
+package templating
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Context contém o estado usado na renderização de uma requisição
+type Context struct {
+	// WorkerID identifica o worker (usuário virtual) que envia a requisição
+	WorkerID int
+	// Vars contém as variáveis do usuário virtual, referenciadas pelo nome
+	Vars map[string]string
+	// Counter é o contador compartilhado por todas as requisições do teste
+	Counter *atomic.Uint64
+}
+
+// Template é um texto compilado com referências {{...}}
+type Template struct {
+	parts []part
+	// size é o tamanho do texto fixo, usado para pré-alocar a saída
+	size int
+}
+
+// part é um trecho do template: um texto fixo ou uma expressão
+type part struct {
+	text string
+	eval func(*Context) (string, error)
+}
+
+// Compile interpreta o texto e valida as expressões. Expressões são um nome
+// seguido de argumentos separados por espaços, como {{randInt 1 1000}};
+// nomes que não são funções embutidas referenciam variáveis do usuário.
+// Um {{ literal é escrito como \{{
+func Compile(text string) (*Template, error) {
+	t := &Template{}
+
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			break
+		}
+		if start > 0 && text[start-1] == '\\' {
+			t.addText(text[:start-1] + "{{")
+			text = text[start+2:]
+			continue
+		}
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("template sem fechamento: %q", text[start:])
+		}
+
+		if start > 0 {
+			t.addText(text[:start])
+		}
+
+		expression := text[start+2 : start+end]
+		eval, err := compileExpression(expression)
+		if err != nil {
+			return nil, fmt.Errorf("expressão {{%s}} inválida: %w", strings.TrimSpace(expression), err)
+		}
+		t.parts = append(t.parts, part{eval: eval})
+
+		text = text[start+end+2:]
+	}
+
+	if text != "" {
+		t.addText(text)
+	}
+
+	return t, nil
+}
+
+// addText acrescenta um texto fixo, unindo-o ao anterior quando possível
+func (t *Template) addText(text string) {
+	t.size += len(text)
+	if last := len(t.parts) - 1; last >= 0 && t.parts[last].eval == nil {
+		t.parts[last].text += text
+		return
+	}
+	t.parts = append(t.parts, part{text: text})
+}
+
+// Static indica que o template não tem expressões dinâmicas e sempre
+// renderiza o mesmo texto
+func (t *Template) Static() bool {
+	for _, p := range t.parts {
+		if p.eval != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Render gera o texto do template para o contexto
+func (t *Template) Render(ctx *Context) (string, error) {
+	if len(t.parts) == 1 && t.parts[0].eval == nil {
+		return t.parts[0].text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(t.size + 16*len(t.parts))
+	for _, p := range t.parts {
+		if p.eval == nil {
+			b.WriteString(p.text)
+			continue
+		}
+		value, err := p.eval(ctx)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(value)
+	}
+
+	return b.String(), nil
+}
+
+// compileExpression converte uma expressão na função que a avalia
+func compileExpression(expression string) (func(*Context) (string, error), error) {
+	args, err := splitArgs(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("expressão vazia")
+	}
+
+	name, args := args[0], args[1:]
+	switch name {
+	case "uuid":
+		if err := expectArgs(args, 0); err != nil {
+			return nil, err
+		}
+		return func(*Context) (string, error) { return newUUID(), nil }, nil
+
+	case "randInt":
+		if err := expectArgs(args, 2); err != nil {
+			return nil, err
+		}
+		min, errMin := strconv.Atoi(args[0])
+		max, errMax := strconv.Atoi(args[1])
+		if errMin != nil || errMax != nil || max < min {
+			return nil, fmt.Errorf("use randInt MIN MAX com inteiros e MIN <= MAX")
+		}
+		span := max - min + 1
+		return func(*Context) (string, error) {
+			return strconv.Itoa(min + mathrand.Intn(span)), nil
+		}, nil
+
+	case "counter":
+		if err := expectArgs(args, 0); err != nil {
+			return nil, err
+		}
+		return func(ctx *Context) (string, error) {
+			return strconv.FormatUint(ctx.Counter.Add(1), 10), nil
+		}, nil
+
+	case "timestamp":
+		// Sem argumentos, milissegundos desde a época Unix; com um argumento,
+		// o layout de formatação do pacote time
+		if len(args) > 1 {
+			return nil, fmt.Errorf("use timestamp ou timestamp LAYOUT")
+		}
+		if len(args) == 1 {
+			layout := args[0]
+			return func(*Context) (string, error) { return time.Now().Format(layout), nil }, nil
+		}
+		return func(*Context) (string, error) {
+			return strconv.FormatInt(time.Now().UnixMilli(), 10), nil
+		}, nil
+
+	case "workerID":
+		if err := expectArgs(args, 0); err != nil {
+			return nil, err
+		}
+		return func(ctx *Context) (string, error) { return strconv.Itoa(ctx.WorkerID), nil }, nil
+
+	case "env":
+		// As variáveis de ambiente são lidas uma única vez, na compilação
+		if err := expectArgs(args, 1); err != nil {
+			return nil, err
+		}
+		value, ok := os.LookupEnv(args[0])
+		if !ok {
+			return nil, fmt.Errorf("variável de ambiente %s não definida", args[0])
+		}
+		return func(*Context) (string, error) { return value, nil }, nil
+
+	default:
+		if err := expectArgs(args, 0); err != nil {
+			return nil, fmt.Errorf("função desconhecida %q", name)
+		}
+		return func(ctx *Context) (string, error) {
+			value, ok := ctx.Vars[name]
+			if !ok {
+				return "", fmt.Errorf("variável %q não definida", name)
+			}
+			return value, nil
+		}, nil
+	}
+}
+
+// splitArgs separa a expressão em palavras; argumentos entre aspas podem conter espaços
+func splitArgs(expression string) ([]string, error) {
+	var args []string
+	rest := strings.TrimSpace(expression)
+
+	for rest != "" {
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("texto entre aspas inválido")
+			}
+			arg, _ := strconv.Unquote(quoted)
+			args = append(args, arg)
+			rest = strings.TrimSpace(rest[len(quoted):])
+			continue
+		}
+
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		args = append(args, rest[:end])
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	return args, nil
+}
+
+// expectArgs valida a quantidade de argumentos de uma função
+func expectArgs(args []string, count int) error {
+	if len(args) != count {
+		return fmt.Errorf("esperados %d argumentos, recebidos %d", count, len(args))
+	}
+	return nil
+}
+
+// newUUID gera um UUID aleatório (versão 4)
+func newUUID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		// Sem fonte criptográfica disponível, recorre ao gerador pseudoaleatório
+		for i := range id {
+			id[i] = byte(mathrand.Intn(256))
+		}
+	}
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+	return string(buf[:])
+}
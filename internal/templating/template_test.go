@@ -0,0 +1,98 @@
+package templating
+
+import (
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRenderVariablesAndBuiltins(t *testing.T) {
+	t.Setenv("STRESSTEST_REGION", "sa-east-1")
+
+	template, err := Compile(`/users/{{ id }}?w={{workerID}}&n={{counter}}&r={{env "STRESSTEST_REGION"}}`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := &Context{WorkerID: 3, Vars: map[string]string{"id": "42"}, Counter: new(atomic.Uint64)}
+	for i := 1; i <= 2; i++ {
+		got, err := template.Render(ctx)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "/users/42?w=3&n=" + strconv.Itoa(i) + "&r=sa-east-1"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	}
+
+	if _, err := template.Render(&Context{Counter: new(atomic.Uint64)}); err == nil {
+		t.Error("Expected an error for an undefined variable")
+	}
+}
+
+func TestRenderEscapedBraces(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		static   bool
+	}{
+		{`\{{literal}}`, "{{literal}}", true},
+		{`{"a":"\{{x}}","b":"{{id}}"}`, `{"a":"{{x}}","b":"42"}`, false},
+		{`\{{ sem fechamento`, "{{ sem fechamento", true},
+	}
+
+	for _, tt := range tests {
+		template, err := Compile(tt.text)
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", tt.text, err)
+		}
+		if template.Static() != tt.static {
+			t.Errorf("Expected Static() to be %v for %q", tt.static, tt.text)
+		}
+		got, err := template.Render(&Context{Vars: map[string]string{"id": "42"}})
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", tt.text, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestRandomBuiltins(t *testing.T) {
+	template, err := Compile("{{uuid}} {{randInt 5 7}}")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12} [5-7]$`)
+	for i := 0; i < 100; i++ {
+		got, _ := template.Render(&Context{})
+		if !pattern.MatchString(got) {
+			t.Fatalf("Expected a UUID and a number between 5 and 7, got %q", got)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	invalid := []string{
+		"{{uuid",
+		"{{}}",
+		"{{randInt 10 1}}",
+		"{{unknown 1}}",
+		`{{env "STRESSTEST_UNDEFINED_VARIABLE"}}`,
+	}
+
+	for _, text := range invalid {
+		if _, err := Compile(text); err == nil {
+			t.Errorf("Expected an error compiling %q", text)
+		}
+	}
+
+	template, _ := Compile("sem expressões")
+	if !template.Static() {
+		t.Error("Expected a template without expressions to be static")
+	}
+}
@@ -0,0 +1,210 @@
+// Package threshold avalia limites (SLOs) como p95<250ms, error_rate<1% e
+// rps>500 sobre o relatório de um teste, para uso como critério de aprovação
+package threshold
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+// kind é a unidade de uma métrica, que define como o limite é interpretado
+type kind int
+
+const (
+	// latency é uma duração (250ms, 1.5s)
+	latency kind = iota
+	// percent é um percentual (1% ou 1)
+	percent
+	// number é um valor sem unidade
+	number
+)
+
+// metric é uma métrica do relatório que pode ser limitada
+type metric struct {
+	kind  kind
+	value func(models.TestReport) float64
+}
+
+// metrics associa os nomes aceitos nas expressões às métricas do relatório.
+// Latências são comparadas em nanossegundos e taxas em percentual
+var metrics = map[string]metric{
+	"avg":   latencyMetric(func(r models.TestReport) time.Duration { return r.AvgResponseTime }),
+	"min":   latencyMetric(func(r models.TestReport) time.Duration { return r.MinResponseTime }),
+	"max":   latencyMetric(func(r models.TestReport) time.Duration { return r.MaxResponseTime }),
+	"p50":   latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P50 }),
+	"p75":   latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P75 }),
+	"p90":   latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P90 }),
+	"p95":   latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P95 }),
+	"p99":   latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P99 }),
+	"p99.9": latencyMetric(func(r models.TestReport) time.Duration { return r.Percentiles.P999 }),
+	"error_rate": {kind: percent, value: func(r models.TestReport) float64 {
+		return rate(r.FailedReqs, r.TotalRequests)
+	}},
+	"success_rate": {kind: percent, value: func(r models.TestReport) float64 {
+		return rate(r.SuccessfulReqs, r.TotalRequests)
+	}},
+	"check_failure_rate": {kind: percent, value: func(r models.TestReport) float64 {
+		return rate(r.CheckFailures, r.TotalRequests)
+	}},
+	"rps":      {kind: number, value: func(r models.TestReport) float64 { return r.RequestsPerSec }},
+	"requests": {kind: number, value: func(r models.TestReport) float64 { return float64(r.TotalRequests) }},
+	"dropped":  {kind: number, value: func(r models.TestReport) float64 { return float64(r.DroppedIterations) }},
+}
+
+// latencyMetric cria uma métrica de latência a partir do campo do relatório
+func latencyMetric(field func(models.TestReport) time.Duration) metric {
+	return metric{kind: latency, value: func(r models.TestReport) float64 { return float64(field(r)) }}
+}
+
+// rate calcula o percentual de part em total; zero sem requisições
+func rate(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// expressionPattern separa a métrica, o operador e o limite de uma expressão
+var expressionPattern = regexp.MustCompile(`^([a-z0-9_.]+)\s*(<=|>=|<|>)\s*(\S+)$`)
+
+// Threshold é um limite já interpretado
+type Threshold struct {
+	// Expression é a expressão original, exibida no relatório
+	Expression string
+	metric     metric
+	operator   string
+	limit      float64
+}
+
+// Parse interpreta uma expressão no formato métrica operador limite
+func Parse(expression string) (Threshold, error) {
+	expression = strings.TrimSpace(expression)
+	match := expressionPattern.FindStringSubmatch(strings.ToLower(expression))
+	if match == nil {
+		return Threshold{}, fmt.Errorf("limite inválido %q: use métrica, operador (<, <=, > ou >=) e valor, como p95<250ms", expression)
+	}
+
+	name := match[1]
+	if name == "p999" {
+		name = "p99.9"
+	}
+	m, ok := metrics[name]
+	if !ok {
+		return Threshold{}, fmt.Errorf("métrica desconhecida %q no limite %q: use %s", match[1], expression, strings.Join(Names(), ", "))
+	}
+
+	limit, err := parseLimit(m.kind, match[3])
+	if err != nil {
+		return Threshold{}, fmt.Errorf("valor inválido no limite %q: %w", expression, err)
+	}
+
+	return Threshold{Expression: expression, metric: m, operator: match[2], limit: limit}, nil
+}
+
+// ParseAll interpreta uma lista de expressões
+func ParseAll(expressions []string) ([]Threshold, error) {
+	thresholds := make([]Threshold, 0, len(expressions))
+	for _, expression := range expressions {
+		threshold, err := Parse(expression)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// Names retorna os nomes das métricas aceitas, em ordem alfabética
+func Names() []string {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseLimit converte o limite conforme a unidade da métrica
+func parseLimit(k kind, text string) (float64, error) {
+	switch k {
+	case latency:
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return 0, fmt.Errorf("informe uma duração, como 250ms ou 1s")
+		}
+		return float64(duration), nil
+	case percent:
+		value, err := strconv.ParseFloat(strings.TrimSuffix(text, "%"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("informe um percentual, como 1%%")
+		}
+		return value, nil
+	default:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("informe um número")
+		}
+		return value, nil
+	}
+}
+
+// Evaluate compara cada limite com o relatório. Um teste sem requisições
+// reprova todos os limites, já que nenhuma métrica foi medida
+func Evaluate(report models.TestReport, thresholds []Threshold) []models.ThresholdResult {
+	results := make([]models.ThresholdResult, 0, len(thresholds))
+
+	for _, threshold := range thresholds {
+		result := models.ThresholdResult{Expression: threshold.Expression, Actual: "sem requisições"}
+		if report.TotalRequests > 0 {
+			value := threshold.metric.value(report)
+			result.Actual = format(threshold.metric.kind, value)
+			result.Passed = compare(value, threshold.operator, threshold.limit)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Passed indica se todos os limites foram atendidos
+func Passed(results []models.ThresholdResult) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// compare aplica o operador do limite ao valor medido
+func compare(value float64, operator string, limit float64) bool {
+	switch operator {
+	case "<":
+		return value < limit
+	case "<=":
+		return value <= limit
+	case ">":
+		return value > limit
+	default:
+		return value >= limit
+	}
+}
+
+// format formata o valor medido conforme a unidade da métrica
+func format(k kind, value float64) string {
+	switch k {
+	case latency:
+		return time.Duration(value).Round(100 * time.Microsecond).String()
+	case percent:
+		return fmt.Sprintf("%.2f%%", value)
+	default:
+		return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+	}
+}
@@ -0,0 +1,65 @@
+package threshold
+
+import (
+	"testing"
+	"time"
+
+	"stresstest/internal/models"
+)
+
+func TestEvaluate(t *testing.T) {
+	report := models.TestReport{
+		TotalRequests:  1000,
+		SuccessfulReqs: 985,
+		FailedReqs:     15,
+		RequestsPerSec: 512.4,
+		Percentiles:    models.Percentiles{P95: 180 * time.Millisecond, P999: 900 * time.Millisecond},
+	}
+
+	tests := []struct {
+		expression string
+		passed     bool
+		actual     string
+	}{
+		{"p95<250ms", true, "180ms"},
+		{"p95 < 150ms", false, "180ms"},
+		{"P999<=900ms", true, "900ms"},
+		{"error_rate<1%", false, "1.50%"},
+		{"error_rate<2", true, "1.50%"},
+		{"success_rate>=98.5%", true, "98.50%"},
+		{"rps>500", true, "512.4"},
+		{"requests>1000", false, "1000"},
+	}
+
+	for _, test := range tests {
+		threshold, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", test.expression, err)
+		}
+
+		result := Evaluate(report, []Threshold{threshold})[0]
+		if result.Passed != test.passed || result.Actual != test.actual {
+			t.Errorf("Expected %q to be %v with %s, got %v with %s",
+				test.expression, test.passed, test.actual, result.Passed, result.Actual)
+		}
+	}
+}
+
+func TestEvaluateWithoutRequests(t *testing.T) {
+	thresholds, err := ParseAll([]string{"error_rate<1%"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if results := Evaluate(models.TestReport{}, thresholds); Passed(results) {
+		t.Error("Expected thresholds to fail without requests")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expression := range []string{"p95", "p95=250ms", "latency<1s", "p95<250", "error_rate<muito", "rps>rápido"} {
+		if _, err := Parse(expression); err == nil {
+			t.Errorf("Expected an error for %q", expression)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Package version identifica o binário em execução. Os valores são definidos
+// na compilação com -ldflags, por exemplo:
+//
+//	go build -ldflags "-X stresstest/internal/version.Version=1.4.0 -X stresstest/internal/version.BuildTime=2024-05-01T12:00:00Z"
+package version
+
+var (
+	// Version é a versão do binário; "dev" em compilações locais
+	Version = "dev"
+	// BuildTime é o instante da compilação; vazio quando não informado
+	BuildTime = ""
+)